@@ -0,0 +1,120 @@
+// file: cmd/hello-tool-base/configcmd.go
+package main
+
+// configcmd.go implements the "config" subcommands, which work with configuration
+// files offline: exporting their JSON Schema and validating them before a deploy.
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/cockroachdb/errors"
+	"github.com/dkoosis/hello-tool-base/internal/config"
+	"github.com/dkoosis/hello-tool-base/internal/logging"
+)
+
+const configUsage = `usage:
+  hello-tool-base config schema
+  hello-tool-base config validate [--env name] [--strict-env] <file>
+  hello-tool-base config dump [--env name] <file>
+`
+
+// errConfigInvalid is returned by "config validate" and "config dump" after they have
+// printed the problems.
+var errConfigInvalid = errors.New("configuration is invalid")
+
+// runConfigCommand runs "config schema", which prints the JSON Schema of the
+// configuration file, "config validate", which loads and validates a file, or
+// "config dump", which prints a file merged with its includes, conf.d fragments and
+// overlay.
+func runConfigCommand(args []string, stdout io.Writer) error {
+	if len(args) == 0 {
+		_, _ = fmt.Fprint(stdout, configUsage)
+		return errors.New("runConfigCommand: missing subcommand")
+	}
+	switch args[0] {
+	case "schema":
+		enc := json.NewEncoder(stdout)
+		enc.SetIndent("", "  ")
+		return enc.Encode(config.Schema())
+	case "validate":
+		return validateConfig(args[1:], stdout)
+	case "dump":
+		return dumpConfig(args[1:], stdout)
+	default:
+		_, _ = fmt.Fprint(stdout, configUsage)
+		return errors.Newf("runConfigCommand: unknown subcommand %q", args[0])
+	}
+}
+
+// validateConfig runs the full loader, including the overlay file, environment
+// variables and secret references, and validation on a file. It prints the resolved
+// settings when the file is valid and every problem otherwise, returning
+// errConfigInvalid so the process exits non-zero.
+func validateConfig(args []string, stdout io.Writer) error {
+	flags := flag.NewFlagSet("config validate", flag.ContinueOnError)
+	environment := flags.String("env", os.Getenv("APP_ENV"), "environment whose overlay file is applied over the file (env APP_ENV)")
+	strictEnv := flags.Bool("strict-env", false, "treat malformed configuration environment variables as errors")
+	if err := flags.Parse(args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return nil
+		}
+		return err
+	}
+	if flags.NArg() != 1 {
+		_, _ = fmt.Fprint(stdout, configUsage)
+		return errors.New("validateConfig: expected exactly one file")
+	}
+	path := flags.Arg(0)
+
+	// Only problems belong in the report; the loader's progress logs do not.
+	logging.SetupDefaultLogger("error")
+
+	if _, err := os.Stat(path); err != nil {
+		// The loader skips a missing file, which would make any typo in path "valid".
+		_, _ = fmt.Fprintf(stdout, "%s: invalid\n  %v\n", path, err)
+		return errConfigInvalid
+	}
+	loader := &config.Loader{Path: path, Environment: *environment, StrictEnv: *strictEnv}
+	cfg, sources, err := loader.Load()
+	if err != nil {
+		if _, werr := fmt.Fprintf(stdout, "%s: invalid\n", path); werr != nil {
+			return werr
+		}
+		if werr := config.WriteProblems(stdout, err); werr != nil {
+			return werr
+		}
+		return errConfigInvalid
+	}
+	if _, err := fmt.Fprintf(stdout, "%s: valid\n\n", path); err != nil {
+		return err
+	}
+	return config.WriteReport(stdout, config.Report(cfg, sources))
+}
+
+// dumpConfig prints the file merged with its includes, conf.d fragments and overlay
+// as YAML, each value commented with the file it came from.
+func dumpConfig(args []string, stdout io.Writer) error {
+	flags := flag.NewFlagSet("config dump", flag.ContinueOnError)
+	environment := flags.String("env", os.Getenv("APP_ENV"), "environment whose overlay file is applied over the file (env APP_ENV)")
+	if err := flags.Parse(args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return nil
+		}
+		return err
+	}
+	if flags.NArg() != 1 {
+		_, _ = fmt.Fprint(stdout, configUsage)
+		return errors.New("dumpConfig: expected exactly one file")
+	}
+	loader := &config.Loader{Path: flags.Arg(0), Environment: *environment}
+	if err := loader.Dump(stdout); err != nil {
+		_, _ = fmt.Fprintf(stdout, "%s: invalid\n", flags.Arg(0))
+		_ = config.WriteProblems(stdout, err)
+		return errConfigInvalid
+	}
+	return nil
+}
@@ -0,0 +1,91 @@
+// file: cmd/hello-tool-base/loglevels.go
+package main
+
+// loglevels.go implements /admin/log-levels, which raises or lowers the log level of
+// components at runtime. Overrides are held in memory only and revert after a TTL,
+// bounded by logging.overrideTtl, so a forgotten debug override does not outlive the
+// investigation it was set for.
+
+import (
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/dkoosis/hello-tool-base/internal/apperrors"
+	"github.com/dkoosis/hello-tool-base/internal/logging"
+	"github.com/dkoosis/hello-tool-base/internal/middleware"
+	"github.com/dkoosis/hello-tool-base/internal/respond"
+)
+
+// logLevelRequest is the body of PUT /admin/log-levels.
+type logLevelRequest struct {
+	// Component is a component name or a glob pattern such as "mcp*".
+	Component string `json:"component"`
+	Level     string `json:"level"`
+	// TTL is a duration such as "5m"; empty means logging.overrideTtl.
+	TTL string `json:"ttl"`
+}
+
+// logLevelsHandler serves the active overrides and the current level of every
+// component that has logged through GetLogger.
+func (a *app) logLevelsHandler(w http.ResponseWriter, r *http.Request) {
+	respond.JSON(middleware.GetLoggerFromContext(r.Context()), w, http.StatusOK, map[string]interface{}{
+		"overrides":  a.levels.Overrides(),
+		"components": a.levels.Components(),
+	})
+}
+
+// setLogLevelHandler overrides the level of the components matching a pattern until
+// the requested TTL elapses.
+func (a *app) setLogLevelHandler(w http.ResponseWriter, r *http.Request) {
+	log := middleware.GetLoggerFromContext(r.Context())
+	var req logLevelRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respond.Error(log, w, apperrors.NewInvalidRequestError("setLogLevelHandler: request body must be a JSON object", err, nil))
+		return
+	}
+
+	maxTTL := a.config.Config().Logging.OverrideTTL
+	var violations []apperrors.Violation
+	if req.Component == "" {
+		violations = append(violations, apperrors.Violation{Field: "component", Message: "must be a component name or glob pattern."})
+	}
+	level, err := logging.ParseLevel(req.Level)
+	if err != nil || req.Level == "" {
+		violations = append(violations, apperrors.Violation{Field: "level", Message: "must be one of debug, info, warn, error."})
+	}
+	ttl := maxTTL
+	if req.TTL != "" {
+		ttl, err = time.ParseDuration(req.TTL)
+		if err != nil || ttl <= 0 || ttl > maxTTL {
+			violations = append(violations, apperrors.Violation{Field: "ttl", Message: "must be a positive duration of at most " + maxTTL.String() + "."})
+		}
+	}
+	if len(violations) > 0 {
+		respond.Error(log, w, apperrors.NewValidationError("setLogLevelHandler: invalid log level override", violations, nil))
+		return
+	}
+
+	override, err := a.levels.Set(req.Component, level, ttl)
+	if err != nil {
+		respond.Error(log, w, apperrors.NewValidationError("setLogLevelHandler: invalid log level override",
+			[]apperrors.Violation{{Field: "component", Message: "must be a component name or glob pattern."}}, nil))
+		return
+	}
+	log.Info("Log level override set.", "pattern", override.Pattern, "level", override.Level, "ttl", ttl.String())
+	respond.JSON(log, w, http.StatusOK, override)
+}
+
+// resetLogLevelHandler removes the override of the pattern given as the component
+// query parameter, restoring the configured level.
+func (a *app) resetLogLevelHandler(w http.ResponseWriter, r *http.Request) {
+	log := middleware.GetLoggerFromContext(r.Context())
+	pattern := r.URL.Query().Get("component")
+	if !a.levels.Reset(pattern) {
+		respond.Error(log, w, apperrors.NewResourceError(apperrors.ErrResourceNotFound,
+			"resetLogLevelHandler: no log level override for "+pattern, nil, nil))
+		return
+	}
+	log.Info("Log level override removed.", "pattern", pattern)
+	w.WriteHeader(http.StatusNoContent)
+}
@@ -5,102 +5,60 @@ package main
 
 import (
 	"context"
-	"encoding/json"
+	"flag"
 	"fmt"
+	"io"
+	"maps"
 	"net/http"
 	"os"
 	"os/signal"
+	"strconv"
 	"syscall"
+	"time"
 
-	// Added for server timeouts
 	"github.com/cockroachdb/errors"
+	hellotoolbase "github.com/dkoosis/hello-tool-base"
 	"github.com/dkoosis/hello-tool-base/internal/apperrors"
 	"github.com/dkoosis/hello-tool-base/internal/buildinfo"
 	"github.com/dkoosis/hello-tool-base/internal/config"
+	"github.com/dkoosis/hello-tool-base/internal/featureflags"
+	"github.com/dkoosis/hello-tool-base/internal/jsonrpc"
 	"github.com/dkoosis/hello-tool-base/internal/logging"
+	"github.com/dkoosis/hello-tool-base/internal/mcp"
 	"github.com/dkoosis/hello-tool-base/internal/middleware"
+	"github.com/dkoosis/hello-tool-base/internal/openapi"
+	"github.com/dkoosis/hello-tool-base/internal/respond"
+	"github.com/dkoosis/hello-tool-base/internal/server"
+	"github.com/dkoosis/hello-tool-base/internal/tools"
+	"github.com/dkoosis/hello-tool-base/internal/transport"
 )
 
-var (
-	// appLog is the global logger, primarily for startup and shutdown messages.
-	// Request-specific logging will use the logger from context.
-	appLog logging.Logger
-	// cfg holds the application configuration, moved to package level for access in main.
-	cfg *config.Config
-)
-
-// GreetingResponse defines the structure for a successful greeting.
-type GreetingResponse struct {
-	Message string `json:"message"`
-}
-
-// ClientErrorResponse defines the structure for a JSON error message to the client.
-type ClientErrorResponse struct {
-	Error   string `json:"error"`
-	Details string `json:"details,omitempty"`
-}
-
-// respondWithJSON is a helper function to respond with JSON.
-// It now takes a logger for consistent error logging.
-func respondWithJSON(l logging.Logger, w http.ResponseWriter, statusCode int, payload interface{}) {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(statusCode)
-	if err := json.NewEncoder(w).Encode(payload); err != nil {
-		// Use the passed-in logger which should have trace_id
-		wrappedErr := errors.Wrap(err, "respondWithJSON: failed to encode JSON response payload")
-		l.Error("Failed to encode JSON response", "error", fmt.Sprintf("%+v", wrappedErr))
-	}
+// app holds the dependencies shared by the HTTP handlers.
+// It is constructed once in run() and injected, so tests can build their own
+// instance instead of mutating package-level state.
+type app struct {
+	// config holds the active configuration and the source of each value. Handlers read
+	// it on every request so reloads take effect without a restart.
+	config *config.Store
+	// flags evaluates the feature flags of the active configuration.
+	flags *featureflags.Flags
+	// levels holds the runtime log levels of the default logger's components; nil
+	// when the default logger does not support them.
+	levels *logging.LevelRegistry
+	// log is the application logger, primarily for startup and shutdown messages.
+	// Request-specific logging uses the logger from context.
+	log logging.Logger
 }
 
-// respondWithError is a helper function to respond with a JSON error to the client.
-// It now takes a logger.
-func respondWithError(l logging.Logger, w http.ResponseWriter, statusCode int, clientMessage string, clientDetails string, internalErr error) {
-	// Use the passed-in logger which should have trace_id
-	l.Error("Server-side error occurred",
-		"internal_error", fmt.Sprintf("%+v", internalErr), // Ensure internalErr is wrapped with stack if not already
-		"client_message", clientMessage,
-		"client_details", clientDetails,
-		"http_status_code", statusCode,
-	)
-	respondWithJSON(l, w, statusCode, ClientErrorResponse{Error: clientMessage, Details: clientDetails})
-}
-
-// helloHandler handles requests to the /hello endpoint.
-// It expects a 'name' query parameter and returns a personalized greeting.
-// If the 'name' parameter is missing, it returns a 400 Bad Request.
-func helloHandler(w http.ResponseWriter, r *http.Request) {
-	// Retrieve the request-scoped logger from context
-	reqLogger := middleware.GetLoggerFromContext(r.Context()).WithField("handler", "helloHandler")
-	reqLogger.Info("Received request", "remote_addr", r.RemoteAddr, "path", r.URL.Path)
-
-	name := r.URL.Query().Get("name")
-	if name == "" {
-		internalErr := apperrors.NewInvalidParamsError(
-			"helloHandler: missing 'name' query parameter",
-			nil, // No underlying cause for this specific validation error
-			map[string]interface{}{
-				"parameter_name": "name",
-				"query_path":     r.URL.String(),
-				"method":         r.Method,
-			},
-		)
-		respondWithError(reqLogger, w, http.StatusBadRequest,
-			"Invalid Request Parameter",
-			"The 'name' query parameter is required.",
-			internalErr)
-		return
-	}
-
-	message := fmt.Sprintf("Hello, %s, from your Go Cloud Run service!", name)
-	response := GreetingResponse{Message: message}
-	respondWithJSON(reqLogger, w, http.StatusOK, response)
-	reqLogger.Info("Successfully processed /hello request", "name", name)
+// newApp creates the handler dependencies for the given configuration store and logger.
+func newApp(store *config.Store, log logging.Logger) *app {
+	return &app{config: store, flags: featureflags.New(store), levels: logging.DefaultLevels(), log: log}
 }
 
 // rootHandler handles requests to the / (root) endpoint.
 // It returns general service information, including build details and a welcome message.
 // For any other path, it returns a 404 Not Found.
-func rootHandler(w http.ResponseWriter, r *http.Request) {
+func (a *app) rootHandler(w http.ResponseWriter, r *http.Request) {
 	reqLogger := middleware.GetLoggerFromContext(r.Context()).WithField("handler", "rootHandler")
 
 	if r.URL.Path != "/" {
@@ -124,14 +82,15 @@ func rootHandler(w http.ResponseWriter, r *http.Request) {
 
 	writeAndLog("Hello World Root! This is the base Go service. Try /hello?name=YourName\n")
 	writeAndLog("---\n")
-	serviceName := cfg.Server.Name // Use configured server name
+	serviceName := a.config.Config().Server.Name // Use configured server name
 	if serviceName == "" {
 		serviceName = "hello-tool-base" // Fallback
 	}
 	writeAndLog("Service: %s\n", serviceName)
-	writeAndLog("Version: %s\n", buildinfo.Version)
-	writeAndLog("Commit:  %s\n", buildinfo.CommitHash)
-	writeAndLog("Built:   %s\n", buildinfo.BuildDate)
+	info := buildinfo.Get()
+	writeAndLog("Version: %s\n", info.Version)
+	writeAndLog("Commit:  %s\n", info.Commit)
+	writeAndLog("Built:   %s\n", info.BuildDate)
 
 	// Fetch and write the TraceID
 	traceID := middleware.GetTraceIDFromContext(r.Context())
@@ -142,113 +101,284 @@ func rootHandler(w http.ResponseWriter, r *http.Request) {
 	reqLogger.Info("Successfully processed / request")
 }
 
-// healthHandler provides a health check endpoint for the service.
-// It returns the service's current operational status, version, commit, and build date.
-func healthHandler(w http.ResponseWriter, r *http.Request) {
-	reqLogger := middleware.GetLoggerFromContext(r.Context()).WithField("handler", "healthHandler")
-	// For frequent health checks, detailed logging per request might be too verbose.
-	// reqLogger.Debug("Received health check request", "path", r.URL.Path) // Use Debug if preferred
-
-	healthStatus := struct {
-		Status    string `json:"status"`
-		Version   string `json:"version"`
-		Commit    string `json:"commit"`
-		BuildDate string `json:"buildDate"`
-		TraceID   string `json:"traceId,omitempty"` // Optionally include traceID in health response
-	}{
-		Status:    "OK",
-		Version:   buildinfo.Version,
-		Commit:    buildinfo.CommitHash,
-		BuildDate: buildinfo.BuildDate,
-		TraceID:   middleware.GetTraceIDFromContext(r.Context()), // Get trace ID from context
-	}
-	respondWithJSON(reqLogger, w, http.StatusOK, healthStatus) // Pass logger
-	// reqLogger.Debug("Successfully processed /health request")
+// versionHandler serves the build information, Go version and dependencies as JSON.
+func (a *app) versionHandler(w http.ResponseWriter, r *http.Request) {
+	respond.JSON(middleware.GetLoggerFromContext(r.Context()), w, http.StatusOK, buildinfo.Get())
+}
+
+// configHandler serves the resolved configuration with the source of each value.
+// Secrets are redacted.
+func (a *app) configHandler(w http.ResponseWriter, r *http.Request) {
+	respond.JSON(middleware.GetLoggerFromContext(r.Context()), w, http.StatusOK, map[string]interface{}{
+		"settings": config.Report(a.config.Config(), a.config.Sources()),
+	})
+}
+
+// flagsHandler serves the configured feature flags with their evaluation counts.
+func (a *app) flagsHandler(w http.ResponseWriter, r *http.Request) {
+	respond.JSON(middleware.GetLoggerFromContext(r.Context()), w, http.StatusOK, map[string]interface{}{
+		"flags": a.flags.State(),
+	})
+}
+
+// routes registers the service's tools and remaining HTTP handlers on the server.
+func (a *app) routes(srv *server.Server) error {
+	registry, err := a.toolRegistry()
+	if err != nil {
+		return err
+	}
+	registry.Mount(srv)
+
+	spec, err := openapi.Handler(openAPIDocument(registry))
+	if err != nil {
+		return err
+	}
+	srv.Handle("GET /openapi.yaml", spec)
+	srv.Handle("/rpc", jsonrpc.NewHTTPHandler(registry, a.log))
+	srv.HandleFunc("GET /version", a.versionHandler)
+	cfg := a.config.Config()
+	if cfg.Admin.Token.IsSet() {
+		requireToken := middleware.RequireBearerToken(cfg.Admin.Token.Value())
+		srv.Handle("GET /admin/config", requireToken(http.HandlerFunc(a.configHandler)))
+		srv.Handle("GET /admin/flags", requireToken(http.HandlerFunc(a.flagsHandler)))
+		if a.levels != nil {
+			srv.Handle("GET /admin/log-levels", requireToken(http.HandlerFunc(a.logLevelsHandler)))
+			srv.Handle("PUT /admin/log-levels", requireToken(http.HandlerFunc(a.setLogLevelHandler)))
+			srv.Handle("DELETE /admin/log-levels", requireToken(http.HandlerFunc(a.resetLogLevelHandler)))
+		}
+	} else {
+		a.log.Info("Admin endpoints disabled: no admin token configured.")
+	}
+	if cfg.MCP.Enabled {
+		a.log.Info("MCP HTTP endpoint enabled.", "path", cfg.MCP.Path)
+		srv.Handle(cfg.MCP.Path, a.mcpServer(registry).HTTPHandler())
+	}
+	srv.HandleFunc("/", a.rootHandler)
+	return nil
+}
+
+// mcpServer exposes the registered tools over the Model Context Protocol.
+func (a *app) mcpServer(registry *tools.Registry) *mcp.Server {
+	return mcp.NewServer(registry,
+		mcp.Implementation{Name: a.config.Config().Server.Name, Version: buildinfo.Get().Version},
+		mcp.WithLogger(a.log),
+		mcp.WithSessionLimits(a.config.Config().MCP.SessionIdleTimeout, a.config.Config().MCP.MaxSessions),
+	)
+}
+
+// serveStdio serves MCP over stdin and stdout until the client closes stdin or
+// SIGINT or SIGTERM arrives. Logs go to stderr, keeping stdout for protocol messages.
+func (a *app) serveStdio(ctx context.Context) error {
+	registry, err := a.toolRegistry()
+	if err != nil {
+		return err
+	}
+	ctx, stop := signal.NotifyContext(ctx, syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+	return a.mcpServer(registry).Serve(ctx, transport.NewNDJSONTransport(os.Stdin, os.Stdout, os.Stdin))
+}
+
+// restartRequiredFields are read only at startup: the listener, the middleware chain,
+// the routes and the loggers' outputs are built from them once. The request and
+// graceful timeouts and the log levels are applied on reload.
+var restartRequiredFields = map[string]bool{
+	"server.port":               true,
+	"server.idleTimeout":        true,
+	"server.responseValidation": true,
+	"server.callerHeader":       true,
+	"server.tenantHeader":       true,
+	"mcp.enabled":               true,
+	"mcp.path":                  true,
+	"mcp.stdio":                 true,
+	"mcp.sessionIdleTimeout":    true,
+	"mcp.maxSessions":           true,
+	"admin.token":               true,
+	"logging.format":            true,
+	"logging.addSource":         true,
+	"logging.output":            true,
+	"logging.projectId":         true,
+	"logging.redactKeys":        true,
+	"logging.redactPatterns":    true,
+}
+
+// warnRestartRequired returns a configuration subscriber that warns when a reload
+// changes a field that only takes effect after a restart.
+func warnRestartRequired(log logging.Logger) config.Subscriber {
+	return func(prev, next *config.Config) {
+		for _, change := range config.Diff(prev, next) {
+			if restartRequiredFields[change.Path] {
+				log.Warn("Configuration change takes effect after a restart.", "field", change.Path)
+			}
+		}
+	}
+}
+
+// applyLogLevels returns a configuration subscriber that applies a reloaded base
+// level and component levels to levels.
+func applyLogLevels(levels *logging.LevelRegistry, log logging.Logger) config.Subscriber {
+	return func(prev, next *config.Config) {
+		if prev.Logging.Level == next.Logging.Level && maps.Equal(prev.Logging.Components, next.Logging.Components) {
+			return
+		}
+		if err := levels.Reconfigure(next.Logging.Options()); err != nil {
+			log.Error("Failed to apply reloaded log levels.", "error", fmt.Sprintf("%+v", err))
+			return
+		}
+		log.Info("Log levels reloaded.", "level", next.Logging.Level, "components", next.Logging.Components)
+	}
+}
+
+// envOrDefault returns the value of the environment variable key, or def if it is unset or empty.
+func envOrDefault(key, def string) string {
+	if v := os.Getenv(key); v != "" {
+		return v
+	}
+	return def
 }
 
 // main is the entry point for the application.
-// It initializes configuration, logging, sets up HTTP routes, and starts the server.
-// It also handles graceful shutdown on SIGINT or SIGTERM signals.
+// It delegates to run and exits non-zero if the service fails to start or stop cleanly.
 func main() {
-	// Setup default logger for application-level logs (startup, shutdown)
-	logging.SetupDefaultLogger("debug") // Or your desired default level
-	appLog = logging.GetLogger("hello-tool")
+	if err := run(context.Background(), os.Args[1:], os.Stdout); err != nil {
+		os.Exit(1)
+	}
+}
 
-	cfgPath := os.Getenv("CONFIG_PATH")
-	if cfgPath == "" {
-		cfgPath = "config.yaml"
+// run parses the command-line flags, initializes configuration and logging, sets up
+// HTTP routes, and serves until SIGINT or SIGTERM triggers a graceful shutdown.
+// Failures are logged before being returned. Output requested by flags such as
+// --version goes to stdout. The "config" subcommands are handled by runConfigCommand.
+func run(ctx context.Context, args []string, stdout io.Writer) error {
+	if len(args) > 0 && args[0] == "config" {
+		return runConfigCommand(args[1:], stdout)
 	}
+	flags := flag.NewFlagSet("hello-tool-base", flag.ContinueOnError)
+	showVersion := flags.Bool("version", false, "print build information, Go version and dependencies, then exit")
+	printConfig := flags.Bool("print-config", false, "print the resolved configuration with the source of each value, then exit")
+	cfgPath := flags.String("config", envOrDefault("CONFIG_PATH", "config.yaml"), "base configuration file (env CONFIG_PATH)")
+	environment := flags.String("env", os.Getenv("APP_ENV"), "environment whose overlay file, e.g. config.<env>.yaml, is applied over the base file (env APP_ENV)")
+	strictEnvDefault, _ := strconv.ParseBool(os.Getenv("CONFIG_STRICT_ENV"))
+	strictEnv := flags.Bool("strict-env", strictEnvDefault, "fail startup on a malformed configuration environment variable instead of ignoring it (env CONFIG_STRICT_ENV)")
+	watchInterval := flags.Duration("config-watch-interval", config.DefaultWatchInterval, "how often to check the configuration files for changes; 0 disables polling (SIGHUP always reloads)")
+	configFlags := config.RegisterFlags(flags)
+	if err := flags.Parse(args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return nil
+		}
+		return err
+	}
+	if *showVersion {
+		_, err := fmt.Fprint(stdout, buildinfo.Get().String())
+		return err
+	}
+
+	// Log at info until the configuration, which holds the logging settings, is loaded.
+	logging.SetupDefaultLogger("info")
+	appLog := logging.GetLogger("hello-tool")
 
-	var err error
-	cfg, err = config.LoadFromFile(cfgPath)
+	loader := &config.Loader{Path: *cfgPath, Environment: *environment, Flags: configFlags, StrictEnv: *strictEnv}
+	store, err := config.NewStore(loader)
+	var decodeErrs config.DecodeErrors
+	if errors.As(err, &decodeErrs) {
+		for _, e := range decodeErrs {
+			appLog.Error("Invalid configuration file.", "file", e.File, "line", e.Line, "column", e.Column, "problem", e.Message, "suggestion", e.Suggestion)
+		}
+		appLog.Error("Configuration file is invalid. Shutting down.", "path", *cfgPath, "problems", len(decodeErrs))
+		return err
+	}
+	if violations := apperrors.ViolationsFrom(err); len(violations) > 0 {
+		// Report every invalid field, not just the first, before any port is bound.
+		for _, v := range violations {
+			appLog.Error("Invalid configuration.", "field", v.Field, "problem", v.Message)
+		}
+		appLog.Error("Configuration is invalid. Shutting down.", "path", *cfgPath, "violations", len(violations))
+		return err
+	}
 	if err != nil {
-		// err from LoadFromFile should already be well-wrapped by cockroachdb/errors.
+		// err from Load should already be well-wrapped by cockroachdb/errors.
 		// Logging with %+v will include the stack trace.
-		appLog.Error("Failed to load configuration. Shutting down.", "path", cfgPath, "error", fmt.Sprintf("%+v", err))
-		os.Exit(1)
+		appLog.Error("Failed to load configuration. Shutting down.", "path", *cfgPath, "error", fmt.Sprintf("%+v", err))
+		return err
 	}
+	if *printConfig {
+		return config.WriteReport(stdout, config.Report(store.Config(), store.Sources()))
+	}
+	cfg := store.Config()
+	if err := logging.Setup(cfg.Logging.Options()); err != nil {
+		appLog.Error("Failed to configure logging. Shutting down.", "error", fmt.Sprintf("%+v", err))
+		return err
+	}
+	appLog = logging.GetLogger("hello-tool")
+	store.Subscribe(warnRestartRequired(appLog))
+	if levels := logging.DefaultLevels(); levels != nil {
+		store.Subscribe(applyLogLevels(levels, appLog))
+		// SIGUSR1 switches every component to debug until the override TTL elapses, or
+		// back if it is already on.
+		go levels.HandleSignal(ctx, syscall.SIGUSR1, func() time.Duration {
+			return store.Config().Logging.OverrideTTL
+		})
+	}
+	go store.Watch(ctx, *watchInterval)
 
 	// Use appLog for startup messages
+	info := buildinfo.Get()
 	appLog.Info("Service starting...",
 		"name", cfg.Server.Name,
-		"version", buildinfo.Version,
-		"commit", buildinfo.CommitHash,
-		"buildDate", buildinfo.BuildDate,
+		"version", info.Version,
+		"commit", info.Commit,
+		"buildDate", info.BuildDate,
+		"goVersion", info.GoVersion,
 		"port", cfg.Server.Port,
 	)
 
-	mux := http.NewServeMux()
-	mux.HandleFunc("/hello", helloHandler)
-	mux.HandleFunc("/health", healthHandler)
-	mux.HandleFunc("/", rootHandler)
-
-	// Apply middleware
-	// The Tracing middleware is instantiated with the appLog, which will serve as the
-	// base for request-scoped loggers created by the middleware.
-	tracingMiddleware := middleware.Tracing(appLog)
-	handlerWithTracing := tracingMiddleware(mux)
-	// Add other middleware here if needed, e.g.:
-	// handlerWithAuth := authMiddleware(handlerWithTracing)
-
-	srv := &http.Server{
-		Addr:         fmt.Sprintf(":%d", cfg.Server.Port),
-		Handler:      handlerWithTracing, // Use the middleware-wrapped handler
-		ReadTimeout:  cfg.Server.ReadTimeout,
-		WriteTimeout: cfg.Server.WriteTimeout,
-		IdleTimeout:  cfg.Server.IdleTimeout,
-	}
-
-	go func() {
-		appLog.Info("Server listening", "address", srv.Addr)
-		// errors.Is correctly checks for http.ErrServerClosed
-		if err := srv.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
-			// Error from ListenAndServe might not be wrapped by cockroachdb yet, so wrap it for consistent stack trace.
-			// However, standard library errors like this are often clear enough.
-			// For consistency with prompt, let's ensure it's potentially wrapped if we want to add our own context/type.
-			// But since we just log it, fmt.Sprintf("%+v", err) is often sufficient if it's already rich.
-			// Let's keep it as is, as %+v on stdlib errors can also be informative.
-			// If we were to pass it to apperrors, then we'd wrap:
-			// wrappedErr := errors.Wrap(err, "main: server ListenAndServe failed")
-			appLog.Error("Server failed to start or encountered an error. Shutting down.", "error", fmt.Sprintf("%+v", err))
-			os.Exit(1)
+	a := newApp(store, appLog)
+	if cfg.MCP.Stdio {
+		appLog.Info("Serving MCP over stdio instead of HTTP.")
+		if err := a.serveStdio(ctx); err != nil {
+			appLog.Error("MCP stdio server failed. Shutting down.", "error", fmt.Sprintf("%+v", err))
+			return err
 		}
-	}()
-
-	quit := make(chan os.Signal, 1)
-	signal.Notify(quit, syscall.SIGINT, syscall.SIGTERM)
+		return nil
+	}
 
-	receivedSignal := <-quit
-	appLog.Info("Received signal, initiating shutdown...", "signal", receivedSignal.String())
+	// Requests are validated against the committed contract before reaching a handler.
+	spec, err := openapi.Parse(hellotoolbase.OpenAPISpec)
+	if err != nil {
+		appLog.Error("Failed to load OpenAPI description. Shutting down.", "error", fmt.Sprintf("%+v", err))
+		return err
+	}
 
-	// Create a context with a timeout for graceful shutdown
-	shutdownCtx, cancelShutdown := context.WithTimeout(context.Background(), cfg.Server.GracefulTimeout)
-	defer cancelShutdown()
+	// The server applies the Tracing middleware with appLog as the base for
+	// request-scoped loggers. Add other middleware with server.WithMiddleware, e.g. auth.
+	// Identity runs first so the caller and tenant appear in every request log, then the
+	// access log, so it records the final status of every request.
+	// Response validation, when enabled, runs next so it also checks the 400s
+	// produced by request validation.
+	opts := []server.Option{
+		server.WithLogger(appLog),
+		server.WithConfigSource(store.Config),
+		server.WithMiddleware(middleware.Identity(cfg.Server.CallerHeader, cfg.Server.TenantHeader), middleware.AccessLog()),
+	}
+	if mode := cfg.Server.ResponseValidation; mode == config.ResponseValidationLog || mode == config.ResponseValidationStrict {
+		appLog.Info("Response validation enabled.", "mode", mode)
+		opts = append(opts, server.WithMiddleware(middleware.ResponseValidation(spec, middleware.ResponseValidationOptions{
+			Strict: mode == config.ResponseValidationStrict,
+		})))
+	}
+	opts = append(opts, server.WithMiddleware(middleware.OpenAPIValidation(spec), a.flags.Middleware()))
+	srv, err := server.New(cfg, opts...)
+	if err != nil {
+		appLog.Error("Failed to create server. Shutting down.", "error", fmt.Sprintf("%+v", err))
+		return err
+	}
+	if err := a.routes(srv); err != nil {
+		appLog.Error("Failed to register routes. Shutting down.", "error", fmt.Sprintf("%+v", err))
+		return err
+	}
 
-	// Error from srv.Shutdown might also be a standard library error.
-	if err := srv.Shutdown(shutdownCtx); err != nil {
-		// wrappedErr := errors.Wrap(err, "main: server Shutdown failed")
-		appLog.Error("Server shutdown failed", "error", fmt.Sprintf("%+v", err))
-	} else {
-		appLog.Info("Server exited gracefully.")
+	if err := srv.Run(ctx); err != nil {
+		appLog.Error("Server failed. Shutting down.", "error", fmt.Sprintf("%+v", err))
+		return err
 	}
+	return nil
 }
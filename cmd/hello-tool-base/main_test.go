@@ -2,33 +2,55 @@
 package main
 
 import (
+	"context"
 	"encoding/json"
+	"flag"
+	"log/slog"
 	"net/http"
 	"net/http/httptest"
 	"os" // Keep this if you use it, or remove if not
+	"path/filepath"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
 	// Adjust import path to your actual internal packages
+	hellotoolbase "github.com/dkoosis/hello-tool-base"
+	"github.com/dkoosis/hello-tool-base/internal/apperrors"
 	"github.com/dkoosis/hello-tool-base/internal/buildinfo"
 	"github.com/dkoosis/hello-tool-base/internal/config"
+	"github.com/dkoosis/hello-tool-base/internal/jsonrpc"
 	"github.com/dkoosis/hello-tool-base/internal/logging"
-)
-
-var (
-	log logging.Logger // Declare log variable
+	"github.com/dkoosis/hello-tool-base/internal/logging/logtest"
+	"github.com/dkoosis/hello-tool-base/internal/mcp/mcptest"
+	"github.com/dkoosis/hello-tool-base/internal/middleware"
+	"github.com/dkoosis/hello-tool-base/internal/openapi"
+	"github.com/dkoosis/hello-tool-base/internal/openapi/openapitest"
+	"github.com/dkoosis/hello-tool-base/internal/respond"
+	"github.com/dkoosis/hello-tool-base/internal/server"
 )
 
 // TestMain is executed before any other tests in this package.
 func TestMain(m *testing.M) {
 	logging.SetupDefaultLogger("error")
-	log = logging.GetLogger("main_test")
-	cfg = config.DefaultConfig()
 	os.Exit(m.Run())
 }
 
+// testSpec parses the committed OpenAPI description.
+func testSpec(t *testing.T) *openapi.Document {
+	t.Helper()
+	spec, err := openapi.Parse(hellotoolbase.OpenAPISpec)
+	require.NoError(t, err, "Committed openapi.yaml should parse")
+	return spec
+}
+
+// newTestApp builds handler dependencies backed by default configuration.
+func newTestApp() *app {
+	return newApp(config.NewStaticStore(config.DefaultConfig(), config.Sources{}), logging.GetLogger("main_test"))
+}
+
 // TestHelloHandler_ReturnsGreeting_When_NameParameterProvided (ADR-008 Naming)
 func TestHelloHandler_ReturnsGreeting_When_NameParameterProvided(t *testing.T) {
 	// Arrange
@@ -36,7 +58,7 @@ func TestHelloHandler_ReturnsGreeting_When_NameParameterProvided(t *testing.T) {
 	rr := httptest.NewRecorder()
 
 	// Act
-	helloHandler(rr, req)
+	newTestApp().helloTool().ServeHTTP(rr, req)
 
 	// Assert
 	resp := rr.Result()
@@ -48,6 +70,7 @@ func TestHelloHandler_ReturnsGreeting_When_NameParameterProvided(t *testing.T) {
 	}() // Corrected: Check error from resp.Body.Close()
 
 	assert.Equal(t, http.StatusOK, resp.StatusCode, "Status code should be OK")
+	openapitest.AssertConforms(t, testSpec(t), req, rr)
 
 	var response GreetingResponse
 	err := json.NewDecoder(resp.Body).Decode(&response)
@@ -63,7 +86,7 @@ func TestHelloHandler_ReturnsBadRequest_When_NameParameterMissing(t *testing.T)
 	rr := httptest.NewRecorder()
 
 	// Act
-	helloHandler(rr, req)
+	newTestApp().helloTool().ServeHTTP(rr, req)
 
 	// Assert
 	resp := rr.Result()
@@ -75,8 +98,9 @@ func TestHelloHandler_ReturnsBadRequest_When_NameParameterMissing(t *testing.T)
 	}() // Corrected: Check error from resp.Body.Close()
 
 	assert.Equal(t, http.StatusBadRequest, resp.StatusCode, "Status code should be Bad Request")
+	openapitest.AssertConforms(t, testSpec(t), req, rr)
 
-	var errorResponse ClientErrorResponse
+	var errorResponse respond.ErrorResponse
 	err := json.NewDecoder(resp.Body).Decode(&errorResponse)
 	require.NoError(t, err, "Should be no error decoding JSON error response")
 
@@ -84,6 +108,50 @@ func TestHelloHandler_ReturnsBadRequest_When_NameParameterMissing(t *testing.T)
 	assert.Contains(t, errorResponse.Details, "The 'name' query parameter is required.", "Error details should specify missing 'name'")
 }
 
+// TestHelloHandler_LogsValidationErrorWithTraceID_When_NameParameterMissing (ADR-008 Naming)
+func TestHelloHandler_LogsValidationErrorWithTraceID_When_NameParameterMissing(t *testing.T) {
+	// Arrange
+	log := logtest.New()
+	cfg := config.DefaultConfig()
+	srv, err := server.New(cfg, server.WithLogger(log))
+	require.NoError(t, err)
+	require.NoError(t, newApp(config.NewStaticStore(cfg, config.Sources{}), log).routes(srv))
+	req := httptest.NewRequest(http.MethodGet, "/hello", nil)
+	req.Header.Set(middleware.HeaderCloudTraceContext, "105445aa7843bc8bf206b12000100000/1;o=1")
+
+	// Act
+	srv.Handler().ServeHTTP(httptest.NewRecorder(), req)
+
+	// Assert
+	log.AssertLogged(t, slog.LevelError, "Server-side error occurred",
+		"http_status_code", http.StatusBadRequest,
+		"client_details", "The 'name' query parameter is required.")
+	entries := log.ForTrace("105445aa7843bc8bf206b12000100000")
+	require.NotEmpty(t, entries, "request logs should carry the trace ID")
+	assert.Equal(t, "Server-side error occurred", entries[len(entries)-1].Message)
+}
+
+// TestHelloHandler_ReturnsViolations_When_NameParameterTooLong (ADR-008 Naming)
+func TestHelloHandler_ReturnsViolations_When_NameParameterTooLong(t *testing.T) {
+	// Arrange
+	req := httptest.NewRequest("GET", "/hello?name="+strings.Repeat("a", 101), nil)
+	rr := httptest.NewRecorder()
+
+	// Act
+	newTestApp().helloTool().ServeHTTP(rr, req)
+
+	// Assert
+	assert.Equal(t, http.StatusBadRequest, rr.Code, "Status code should be Bad Request")
+
+	var errorResponse respond.ErrorResponse
+	require.NoError(t, json.NewDecoder(rr.Body).Decode(&errorResponse), "Should be no error decoding JSON error response")
+	assert.Equal(t, -32602, errorResponse.Code, "Code should be the JSON-RPC invalid params code")
+	require.Len(t, errorResponse.Violations, 1, "Exactly one violation should be reported")
+	assert.Equal(t, "name", errorResponse.Violations[0].Field)
+	assert.Equal(t, "query", errorResponse.Violations[0].In)
+	assert.Contains(t, errorResponse.Violations[0].Message, "at most 100 characters")
+}
+
 // TestRootHandler_ReturnsBuildInfo_When_PathIsRoot (ADR-008 Naming)
 func TestRootHandler_ReturnsBuildInfo_When_PathIsRoot(t *testing.T) {
 	// Arrange
@@ -98,16 +166,14 @@ func TestRootHandler_ReturnsBuildInfo_When_PathIsRoot(t *testing.T) {
 		buildinfo.CommitHash = originalCommit
 		buildinfo.BuildDate = originalBuildDate
 	}()
-	if cfg == nil {
-		cfg = config.DefaultConfig()
-	}
-	cfg.Server.Name = "TestService"
+	a := newTestApp()
+	a.config.Config().Server.Name = "TestService"
 
 	req := httptest.NewRequest("GET", "/", nil)
 	rr := httptest.NewRecorder()
 
 	// Act
-	rootHandler(rr, req)
+	a.rootHandler(rr, req)
 
 	// Assert
 	resp := rr.Result()
@@ -136,7 +202,7 @@ func TestRootHandler_ReturnsNotFound_When_PathIsNotRoot(t *testing.T) {
 	rr := httptest.NewRecorder()
 
 	// Act
-	rootHandler(rr, req)
+	newTestApp().rootHandler(rr, req)
 
 	// Assert
 	resp := rr.Result()
@@ -169,7 +235,7 @@ func TestHealthHandler_ReturnsHealthyStatus_When_Called(t *testing.T) {
 	rr := httptest.NewRecorder()
 
 	// Act
-	healthHandler(rr, req)
+	newTestApp().healthTool().ServeHTTP(rr, req)
 
 	// Assert
 	resp := rr.Result()
@@ -181,6 +247,7 @@ func TestHealthHandler_ReturnsHealthyStatus_When_Called(t *testing.T) {
 	}() // Corrected: Check error from resp.Body.Close()
 
 	assert.Equal(t, http.StatusOK, resp.StatusCode, "Status code should be OK")
+	openapitest.AssertConforms(t, testSpec(t), req, rr)
 
 	var healthStatus struct {
 		Status    string `json:"status"`
@@ -196,3 +263,417 @@ func TestHealthHandler_ReturnsHealthyStatus_When_Called(t *testing.T) {
 	assert.Equal(t, "healthSHA", healthStatus.Commit, "Commit should match build info")
 	assert.Equal(t, "2024-02-02T10:00:00Z", healthStatus.BuildDate, "BuildDate should match build info")
 }
+
+// updateOpenAPI regenerates the committed openapi.yaml instead of comparing against it.
+var updateOpenAPI = flag.Bool("update", false, "rewrite openapi.yaml from the registered tools")
+
+// openAPIPath is the committed OpenAPI description, relative to this package.
+const openAPIPath = "../../openapi.yaml"
+
+// TestOpenAPISpec_MatchesCommittedFile_When_GeneratedFromTools (ADR-008 Naming)
+func TestOpenAPISpec_MatchesCommittedFile_When_GeneratedFromTools(t *testing.T) {
+	// Arrange
+	registry, err := newTestApp().toolRegistry()
+	require.NoError(t, err)
+
+	// Act
+	generated, err := openAPIDocument(registry).Marshal()
+	require.NoError(t, err)
+
+	// Assert
+	if *updateOpenAPI {
+		require.NoError(t, os.WriteFile(openAPIPath, generated, 0o644))
+		return
+	}
+	committed, err := os.ReadFile(openAPIPath)
+	require.NoError(t, err)
+	assert.Equal(t, string(generated), string(committed),
+		"openapi.yaml is out of date; run: go test ./cmd/hello-tool-base -run TestOpenAPISpec -update")
+}
+
+// TestOpenAPIValidation_RejectsRequest_When_NameExceedsSpecMaxLength (ADR-008 Naming)
+func TestOpenAPIValidation_RejectsRequest_When_NameExceedsSpecMaxLength(t *testing.T) {
+	// Arrange
+	spec := testSpec(t)
+	reached := false
+	handler := middleware.OpenAPIValidation(spec)(http.HandlerFunc(func(http.ResponseWriter, *http.Request) {
+		reached = true
+	}))
+	req := httptest.NewRequest("GET", "/hello?name="+strings.Repeat("x", 101), nil)
+	rr := httptest.NewRecorder()
+
+	// Act
+	handler.ServeHTTP(rr, req)
+
+	// Assert
+	assert.False(t, reached, "Handler should not run for invalid input")
+	assert.Equal(t, http.StatusBadRequest, rr.Code, "Status code should be Bad Request")
+	var errorResponse respond.ErrorResponse
+	require.NoError(t, json.NewDecoder(rr.Body).Decode(&errorResponse))
+	assert.Equal(t, -32602, errorResponse.Code)
+	require.Len(t, errorResponse.Violations, 1)
+	assert.Equal(t, "/query/name", errorResponse.Violations[0].Pointer)
+}
+
+// TestOpenAPIValidation_RejectsBody_When_LargerThanMaxRequestBodySize (ADR-008 Naming)
+func TestOpenAPIValidation_RejectsBody_When_LargerThanMaxRequestBodySize(t *testing.T) {
+	// Arrange
+	spec, err := openapi.Parse([]byte(`
+openapi: 3.0.3
+info: {title: Test, version: "1"}
+paths:
+  /notes:
+    post:
+      requestBody:
+        required: true
+        content:
+          application/json:
+            schema: {type: object, properties: {text: {type: string}}}
+      responses:
+        "200": {description: OK}
+`))
+	require.NoError(t, err)
+	reached := false
+	handler := middleware.OpenAPIValidation(spec)(http.HandlerFunc(func(http.ResponseWriter, *http.Request) {
+		reached = true
+	}))
+	body := `{"text":"` + strings.Repeat("x", middleware.MaxRequestBodySize) + `"}`
+	req := httptest.NewRequest(http.MethodPost, "/notes", strings.NewReader(body))
+	rr := httptest.NewRecorder()
+
+	// Act
+	handler.ServeHTTP(rr, req)
+
+	// Assert
+	assert.False(t, reached, "Handler should not run for an oversized body")
+	assert.Equal(t, http.StatusBadRequest, rr.Code)
+	var errorResponse respond.ErrorResponse
+	require.NoError(t, json.NewDecoder(rr.Body).Decode(&errorResponse))
+	assert.Equal(t, int(apperrors.ErrInvalidParams), errorResponse.Code)
+	require.Len(t, errorResponse.Violations, 1)
+	assert.Equal(t, "/body", errorResponse.Violations[0].Pointer)
+	assert.Contains(t, errorResponse.Violations[0].Message, "at most 4194304 bytes")
+}
+
+// TestResponseValidation_Returns500WithDiff_When_StrictAndResponseHasUndeclaredField (ADR-008 Naming)
+func TestResponseValidation_Returns500WithDiff_When_StrictAndResponseHasUndeclaredField(t *testing.T) {
+	// Arrange
+	var recorded []apperrors.Violation
+	handler := middleware.ResponseValidation(testSpec(t), middleware.ResponseValidationOptions{
+		Strict: true,
+		OnViolation: func(_ *http.Request, _ int, violations []apperrors.Violation) {
+			recorded = violations
+		},
+	})(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"status":"OK","version":"v1","commit":"abc","uptime":3}`))
+	}))
+	req := httptest.NewRequest("GET", "/health", nil)
+	rr := httptest.NewRecorder()
+
+	// Act
+	handler.ServeHTTP(rr, req)
+
+	// Assert
+	assert.Equal(t, http.StatusInternalServerError, rr.Code, "Strict mode should replace the response with a 500")
+	pointers := make([]string, 0, len(recorded))
+	for _, v := range recorded {
+		pointers = append(pointers, v.Pointer)
+	}
+	assert.ElementsMatch(t, []string{"/response/buildDate", "/response/uptime"}, pointers)
+	var errorResponse respond.ErrorResponse
+	require.NoError(t, json.NewDecoder(rr.Body).Decode(&errorResponse))
+	assert.Contains(t, errorResponse.Details, "/response/buildDate: The 'buildDate' response field is required.")
+}
+
+// TestResponseValidation_PassesWriterThrough_When_RouteNotInSpec (ADR-008 Naming)
+func TestResponseValidation_PassesWriterThrough_When_RouteNotInSpec(t *testing.T) {
+	// Arrange
+	var flushable bool
+	handler := middleware.ResponseValidation(testSpec(t), middleware.ResponseValidationOptions{Strict: true})(
+		http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			_, flushable = w.(http.Flusher)
+			_, _ = w.Write([]byte("streamed"))
+		}))
+	req := httptest.NewRequest(http.MethodPost, "/mcp", nil)
+	rr := httptest.NewRecorder()
+
+	// Act
+	handler.ServeHTTP(rr, req)
+
+	// Assert
+	assert.True(t, flushable, "unmatched routes should get the original, flushable writer")
+	assert.Equal(t, "streamed", rr.Body.String())
+}
+
+// TestMCP_ReturnsGreeting_When_ToolCalledInProcess (ADR-008 Naming)
+func TestMCP_ReturnsGreeting_When_ToolCalledInProcess(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	a := newTestApp()
+	registry, err := a.toolRegistry()
+	require.NoError(t, err)
+	client := mcptest.Start(t, a.mcpServer(registry))
+	_, err = client.Initialize(ctx)
+	require.NoError(t, err)
+
+	// Act
+	result, err := client.CallTool(ctx, "getGreeting", map[string]string{"name": "Ada"})
+
+	// Assert
+	require.NoError(t, err)
+	require.Len(t, result.Content, 1)
+	var greeting GreetingResponse
+	require.NoError(t, json.Unmarshal([]byte(result.Content[0].Text), &greeting))
+	assert.Contains(t, greeting.Message, "Ada")
+}
+
+// TestRPC_ReturnsResultAndInvalidParams_When_BatchMixesValidAndInvalidCalls (ADR-008 Naming)
+func TestRPC_ReturnsResultAndInvalidParams_When_BatchMixesValidAndInvalidCalls(t *testing.T) {
+	// Arrange
+	registry, err := newTestApp().toolRegistry()
+	require.NoError(t, err)
+	handler := middleware.Tracing(logging.GetNoopLogger())(jsonrpc.NewHTTPHandler(registry, logging.GetNoopLogger()))
+	body := `[{"jsonrpc":"2.0","id":1,"method":"getGreeting","params":{"name":"Ada"}},` +
+		`{"jsonrpc":"2.0","id":2,"method":"getGreeting","params":{}}]`
+	req := httptest.NewRequest(http.MethodPost, "/rpc", strings.NewReader(body))
+	rr := httptest.NewRecorder()
+
+	// Act
+	handler.ServeHTTP(rr, req)
+
+	// Assert
+	require.Equal(t, http.StatusOK, rr.Code)
+	var responses []jsonrpc.Response
+	require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &responses))
+	require.Len(t, responses, 2)
+	var greeting GreetingResponse
+	require.NoError(t, json.Unmarshal(responses[0].Result, &greeting))
+	assert.Contains(t, greeting.Message, "Ada")
+	require.NotNil(t, responses[1].Error)
+	assert.Equal(t, int(apperrors.ErrInvalidParams), responses[1].Error.Code)
+}
+
+// TestVersionHandler_ReturnsBuildInfoJSON_When_Called (ADR-008 Naming)
+func TestVersionHandler_ReturnsBuildInfoJSON_When_Called(t *testing.T) {
+	// Arrange
+	req := httptest.NewRequest(http.MethodGet, "/version", nil)
+	rr := httptest.NewRecorder()
+
+	// Act
+	newTestApp().versionHandler(rr, req)
+
+	// Assert
+	require.Equal(t, http.StatusOK, rr.Code)
+	var info buildinfo.Info
+	require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &info))
+	assert.Equal(t, buildinfo.Get().Version, info.Version)
+	assert.NotEmpty(t, info.GoVersion)
+}
+
+// TestRun_PrintsBuildInfo_When_VersionFlagSet (ADR-008 Naming)
+func TestRun_PrintsBuildInfo_When_VersionFlagSet(t *testing.T) {
+	// Arrange
+	var out strings.Builder
+
+	// Act
+	err := run(context.Background(), []string{"--version"}, &out)
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, buildinfo.Get().String(), out.String())
+}
+
+// TestAdminConfig_RequiresTokenAndRedactsSecrets_When_Enabled (ADR-008 Naming)
+func TestAdminConfig_RequiresTokenAndRedactsSecrets_When_Enabled(t *testing.T) {
+	// Arrange
+	cfg := config.DefaultConfig()
+	cfg.Admin.Token = config.NewSecret("s3cret")
+	srv, err := server.New(cfg, server.WithLogger(logging.GetNoopLogger()))
+	require.NoError(t, err)
+	require.NoError(t, newApp(config.NewStaticStore(cfg, config.Sources{}), logging.GetNoopLogger()).routes(srv))
+	handler := srv.Handler()
+
+	anonymous := httptest.NewRequest(http.MethodGet, "/admin/config", nil)
+	authorized := httptest.NewRequest(http.MethodGet, "/admin/config", nil)
+	authorized.Header.Set("Authorization", "Bearer s3cret")
+	anonymousRR, authorizedRR := httptest.NewRecorder(), httptest.NewRecorder()
+
+	// Act
+	handler.ServeHTTP(anonymousRR, anonymous)
+	handler.ServeHTTP(authorizedRR, authorized)
+
+	// Assert
+	assert.Equal(t, http.StatusUnauthorized, anonymousRR.Code)
+	require.Equal(t, http.StatusOK, authorizedRR.Code)
+	assert.Contains(t, authorizedRR.Body.String(), `"path":"server.port"`)
+	assert.NotContains(t, authorizedRR.Body.String(), "s3cret")
+}
+
+// TestGreeting_UsesNewGreetingAndCountsIt_When_FlagEnabledForCaller (ADR-008 Naming)
+func TestGreeting_UsesNewGreetingAndCountsIt_When_FlagEnabledForCaller(t *testing.T) {
+	// Arrange
+	cfg := config.DefaultConfig()
+	cfg.Admin.Token = config.NewSecret("s3cret")
+	cfg.FeatureFlags.Flags = map[string]config.FeatureFlag{
+		"new-greeting": {Enabled: true, Attribute: "caller", Allow: []string{"agent-a"}},
+	}
+	a := newApp(config.NewStaticStore(cfg, config.Sources{}), logging.GetNoopLogger())
+	srv, err := server.New(cfg, server.WithLogger(logging.GetNoopLogger()), server.WithMiddleware(middleware.Identity(cfg.Server.CallerHeader, "")), server.WithMiddleware(a.flags.Middleware()))
+	require.NoError(t, err)
+	require.NoError(t, a.routes(srv))
+	handler := srv.Handler()
+
+	allowed := httptest.NewRequest(http.MethodGet, "/hello?name=Ada", nil)
+	allowed.Header.Set("X-Caller-Id", "agent-a")
+	other := httptest.NewRequest(http.MethodGet, "/hello?name=Ada", nil)
+	state := httptest.NewRequest(http.MethodGet, "/admin/flags", nil)
+	state.Header.Set("Authorization", "Bearer s3cret")
+	allowedRR, otherRR, stateRR := httptest.NewRecorder(), httptest.NewRecorder(), httptest.NewRecorder()
+
+	// Act
+	handler.ServeHTTP(allowedRR, allowed)
+	handler.ServeHTTP(otherRR, other)
+	handler.ServeHTTP(stateRR, state)
+
+	// Assert
+	assert.Contains(t, allowedRR.Body.String(), "Hi Ada")
+	assert.Contains(t, otherRR.Body.String(), "Hello, Ada")
+	require.Equal(t, http.StatusOK, stateRR.Code)
+	assert.Contains(t, stateRR.Body.String(), `"name":"new-greeting"`)
+	assert.Contains(t, stateRR.Body.String(), `"evaluatedOn":1,"evaluatedOff":1`)
+}
+
+// TestAdminLogLevels_SetsListsAndResetsOverrides_When_Authorized (ADR-008 Naming)
+func TestAdminLogLevels_SetsListsAndResetsOverrides_When_Authorized(t *testing.T) {
+	// Arrange
+	cfg := config.DefaultConfig()
+	cfg.Admin.Token = config.NewSecret("s3cret")
+	a := newApp(config.NewStaticStore(cfg, config.Sources{}), logging.GetNoopLogger())
+	levels, err := logging.NewLevelRegistry(slog.LevelInfo, nil)
+	require.NoError(t, err)
+	a.levels = levels
+	srv, err := server.New(cfg, server.WithLogger(logging.GetNoopLogger()))
+	require.NoError(t, err)
+	require.NoError(t, a.routes(srv))
+	handler := srv.Handler()
+	send := func(method, target, body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(method, target, strings.NewReader(body))
+		req.Header.Set("Authorization", "Bearer s3cret")
+		rr := httptest.NewRecorder()
+		handler.ServeHTTP(rr, req)
+		return rr
+	}
+
+	// Act
+	set := send(http.MethodPut, "/admin/log-levels", `{"component":"mcp*","level":"debug","ttl":"5m"}`)
+	tooLong := send(http.MethodPut, "/admin/log-levels", `{"component":"mcp","level":"loud","ttl":"2h"}`)
+	list := send(http.MethodGet, "/admin/log-levels", "")
+	reset := send(http.MethodDelete, "/admin/log-levels?component=mcp*", "")
+	missing := send(http.MethodDelete, "/admin/log-levels?component=mcp*", "")
+
+	// Assert
+	require.Equal(t, http.StatusOK, set.Code, set.Body.String())
+	assert.Contains(t, set.Body.String(), `"component":"mcp*","level":"debug","expiresAt":`)
+	require.Equal(t, http.StatusBadRequest, tooLong.Code)
+	var errorResponse respond.ErrorResponse
+	require.NoError(t, json.NewDecoder(tooLong.Body).Decode(&errorResponse))
+	var violations []string
+	for _, v := range errorResponse.Violations {
+		violations = append(violations, v.Field)
+	}
+	assert.ElementsMatch(t, []string{"level", "ttl"}, violations)
+	require.Equal(t, http.StatusOK, list.Code)
+	assert.Contains(t, list.Body.String(), `"overrides":[{"component":"mcp*","level":"debug"`)
+	assert.Equal(t, http.StatusNoContent, reset.Code)
+	assert.Equal(t, http.StatusNotFound, missing.Code)
+	assert.Empty(t, levels.Overrides())
+}
+
+// TestRun_ReturnsAllViolations_When_ConfigInvalid (ADR-008 Naming)
+func TestRun_ReturnsAllViolations_When_ConfigInvalid(t *testing.T) {
+	// Arrange
+	args := []string{"--config", "", "--server.port=-1", "--server.writeTimeout=0s"}
+
+	// Act
+	err := run(context.Background(), args, &strings.Builder{})
+
+	// Assert
+	require.Error(t, err)
+	var fields []string
+	for _, v := range apperrors.ViolationsFrom(err) {
+		fields = append(fields, v.Field)
+	}
+	assert.ElementsMatch(t, []string{"server.port", "server.writeTimeout"}, fields)
+}
+
+// TestRun_PrintsProblemsAndFails_When_ConfigValidateGivenInvalidFile (ADR-008 Naming)
+func TestRun_PrintsProblemsAndFails_When_ConfigValidateGivenInvalidFile(t *testing.T) {
+	// Arrange
+	dir := t.TempDir()
+	valid := filepath.Join(dir, "valid.yaml")
+	invalid := filepath.Join(dir, "invalid.yaml")
+	require.NoError(t, os.WriteFile(valid, []byte("server:\n  port: 9000\n"), 0o600))
+	require.NoError(t, os.WriteFile(invalid, []byte("server:\n  port: 9000\n  readTimout: 5s\n"), 0o600))
+	var validOut, invalidOut strings.Builder
+
+	// Act
+	validErr := run(context.Background(), []string{"config", "validate", valid}, &validOut)
+	invalidErr := run(context.Background(), []string{"config", "validate", invalid}, &invalidOut)
+
+	// Assert
+	require.NoError(t, validErr)
+	assert.Contains(t, validOut.String(), "valid.yaml: valid")
+	assert.ErrorIs(t, invalidErr, errConfigInvalid)
+	assert.Contains(t, invalidOut.String(), `invalid.yaml:3:3: unknown key "readTimout" in section server (did you mean "readTimeout"?)`)
+}
+
+// TestApplyLogLevels_ReconfiguresRegistry_When_LevelsReloaded (ADR-008 Naming)
+func TestApplyLogLevels_ReconfiguresRegistry_When_LevelsReloaded(t *testing.T) {
+	// Arrange
+	prev := config.DefaultConfig()
+	prev.Logging.Output = filepath.Join(t.TempDir(), "app.log")
+	logger, err := logging.NewLogger(prev.Logging.Options())
+	require.NoError(t, err)
+	logger.WithField("component", "mcp")
+	logger.WithField("component", "tools")
+	next := config.DefaultConfig()
+	next.Logging.Level = "warn"
+	next.Logging.Components = map[string]string{"mcp": "debug"}
+	log := logtest.New()
+
+	// Act
+	applyLogLevels(logger.Levels(), log)(prev, next)
+
+	// Assert
+	assert.Equal(t, map[string]string{"mcp": "debug", "tools": "warn"}, logger.Levels().Components())
+	log.AssertLogged(t, slog.LevelInfo, "Log levels reloaded.", "level", "warn")
+}
+
+// TestAccessLog_LogsRemoteIPWithoutPort_When_RequestCompletes (ADR-008 Naming)
+func TestAccessLog_LogsRemoteIPWithoutPort_When_RequestCompletes(t *testing.T) {
+	tests := map[string]string{
+		"203.0.113.7:52100":  "203.0.113.7",
+		"[2001:db8::1]:8443": "2001:db8::1",
+		"@":                  "@",
+	}
+	for remoteAddr, want := range tests {
+		t.Run(remoteAddr, func(t *testing.T) {
+			// Arrange
+			log := logtest.New()
+			handler := middleware.Tracing(log)(middleware.AccessLog()(http.NotFoundHandler()))
+			req := httptest.NewRequest(http.MethodGet, "/missing", nil)
+			req.RemoteAddr = remoteAddr
+
+			// Act
+			handler.ServeHTTP(httptest.NewRecorder(), req)
+
+			// Assert
+			entries := log.Find(slog.LevelInfo, "Request completed.")
+			require.Len(t, entries, 1)
+			value, _ := entries[0].Value(logging.HTTPRequestKey)
+			require.IsType(t, []slog.Attr{}, value)
+			assert.Contains(t, value, slog.String("remoteIp", want))
+		})
+	}
+}
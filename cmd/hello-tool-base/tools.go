@@ -0,0 +1,116 @@
+// file: cmd/hello-tool-base/tools.go
+package main
+
+// tools.go declares the tools this service exposes. Binding, validation and
+// response encoding are handled by internal/tools; the functions here contain
+// only business logic.
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/dkoosis/hello-tool-base/internal/buildinfo"
+	"github.com/dkoosis/hello-tool-base/internal/middleware"
+	"github.com/dkoosis/hello-tool-base/internal/openapi"
+	"github.com/dkoosis/hello-tool-base/internal/tools"
+)
+
+// GreetingRequest defines the inputs of the greeting tool.
+type GreetingRequest struct {
+	Name string `query:"name" validate:"required,minLength=1,maxLength=100" description:"The name of the person or entity to greet. This name will be included in the greeting message." example:"Alice"`
+}
+
+// GreetingResponse defines the structure for a successful greeting.
+type GreetingResponse struct {
+	Message string `json:"message" description:"The personalized greeting message." example:"Hello, Alice, from your Go Cloud Run service!"`
+}
+
+// HealthRequest is the (empty) input of the health tool.
+type HealthRequest struct{}
+
+// HealthResponse reports the service's operational status and build information.
+type HealthResponse struct {
+	Status    string `json:"status" description:"Current operational status of the service." example:"OK"`
+	Version   string `json:"version" description:"The build version of the service." example:"v1.0.1-alpha"`
+	Commit    string `json:"commit" description:"The Git commit SHA of the build." example:"abcdef1"`
+	BuildDate string `json:"buildDate" format:"date-time" description:"The date and time when the service was built." example:"2023-10-27T10:00:00Z"`
+	TraceID   string `json:"traceId,omitempty" description:"The trace ID of the request, for correlating logs."`
+}
+
+// apiInfo describes the API contract published to Vertex AI in openapi.yaml.
+var apiInfo = openapi.Info{
+	Title: "Hello World Tool API",
+	Description: "A simple API that returns a greeting and provides service health status.\n" +
+		"Intended to be used as a tool by a Google Vertex AI Agent for greetings.\n" +
+		"The health endpoint is primarily for operational monitoring.\n",
+	Version:         "1.1.0", // Bump version for contract changes
+	ToolName:        "HelloWorldTool",
+	ToolDescription: "A tool that greets the user. Takes a name as input and returns a personalized greeting. Use this when the user asks for a greeting or wants to say hello to someone. It can also report its health status.",
+}
+
+// apiServers lists the base URLs published in openapi.yaml.
+// Update the placeholder if/when your service URL changes.
+var apiServers = []openapi.Server{
+	{URL: "https://hello-tool-base-your-project-id.your-region.run.app", Description: "Production Cloud Run URL"},
+}
+
+// openAPIDocument generates the OpenAPI description of the registered tools.
+func openAPIDocument(registry *tools.Registry) *openapi.Document {
+	return registry.OpenAPI(apiInfo, apiServers)
+}
+
+// toolRegistry declares and registers the service's tools.
+func (a *app) toolRegistry() (*tools.Registry, error) {
+	registry := tools.NewRegistry()
+	if err := registry.Register(a.helloTool(), a.healthTool()); err != nil {
+		return nil, err
+	}
+	return registry, nil
+}
+
+// helloTool declares the /hello greeting tool.
+func (a *app) helloTool() *tools.Tool {
+	return tools.New(tools.Spec{
+		Name:                "getGreeting",
+		Method:              http.MethodGet,
+		Path:                "/hello",
+		Summary:             "Get a personalized greeting",
+		Description:         "Returns a personalized greeting message based on the provided name.",
+		ResponseDescription: "A successful and personalized greeting.",
+	}, a.greet)
+}
+
+// healthTool declares the /health check.
+func (a *app) healthTool() *tools.Tool {
+	return tools.New(tools.Spec{
+		Name:                "getHealth",
+		Method:              http.MethodGet,
+		Path:                "/health",
+		Summary:             "Service Health Check",
+		Description:         "Returns the current operational status and build information of the service. This endpoint is primarily for monitoring and operational purposes.",
+		ResponseDescription: "Service is healthy and operating correctly.",
+	}, a.health)
+}
+
+// greet returns a personalized greeting for the validated name. The "new-greeting"
+// feature flag switches to the reworded greeting being rolled out.
+func (a *app) greet(ctx context.Context, req GreetingRequest) (GreetingResponse, error) {
+	middleware.GetLoggerFromContext(ctx).Debug("Building greeting", "name", req.Name)
+	if a.flags.Enabled(ctx, "new-greeting") {
+		return GreetingResponse{Message: fmt.Sprintf("Hi %s, greetings from your Go Cloud Run service!", req.Name)}, nil
+	}
+	return GreetingResponse{Message: fmt.Sprintf("Hello, %s, from your Go Cloud Run service!", req.Name)}, nil
+}
+
+// health returns the service's current operational status, version, commit, and build date.
+func (a *app) health(ctx context.Context, _ HealthRequest) (HealthResponse, error) {
+	info := buildinfo.Get()
+	return HealthResponse{
+		Status:    "OK",
+		Version:   info.Version,
+		Commit:    info.Commit,
+		BuildDate: info.BuildDate,
+		TraceID:   middleware.GetTraceIDFromContext(ctx), // Optionally include traceID in health response
+	}, nil
+}
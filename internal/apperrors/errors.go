@@ -86,6 +86,24 @@ func (e *BaseError) WithContext(key string, value interface{}) *BaseError {
 	return e
 }
 
+// baseError returns the receiver. Because the specific error types embed BaseError,
+// the method is promoted to them, which lets AsBaseError find the shared fields
+// regardless of the concrete type.
+func (e *BaseError) baseError() *BaseError {
+	return e
+}
+
+// AsBaseError finds the first application error in err's chain and returns its BaseError.
+// A plain errors.As against *BaseError does not match the specific error types
+// (e.g. *InvalidParamsError), since they embed BaseError rather than being one.
+func AsBaseError(err error) (*BaseError, bool) {
+	var target interface{ baseError() *BaseError }
+	if !errors.As(err, &target) {
+		return nil, false
+	}
+	return target.baseError(), true
+}
+
 // --- Specific Error Type Structs ---
 // These structs represent categories of errors that can occur in the application.
 // They embed BaseError to inherit common error properties and behaviors.
@@ -200,6 +218,42 @@ func NewInvalidRequestError(message string, cause error, context map[string]inte
 	}
 }
 
+// --- Validation Violations ---
+
+// ViolationsContextKey is the error context key under which NewValidationError stores
+// the list of individual input violations.
+const ViolationsContextKey = "violations"
+
+// Violation describes a single input constraint that a request failed to satisfy.
+// Validation layers collect every violation rather than stopping at the first one,
+// so clients can fix all problems in a single round-trip.
+type Violation struct {
+	Field   string `json:"field" description:"Name of the offending parameter or body field."`
+	In      string `json:"in,omitempty" description:"Where the input was read from: query, header, path or body."`
+	Pointer string `json:"pointer,omitempty" description:"JSON pointer to the failing value within the request, e.g. /query/name or /body/items/0."`
+	Message string `json:"message" description:"Human-readable description of the failure."`
+}
+
+// NewValidationError creates an invalid params error (maps to JSON-RPC -32602) carrying
+// the given violations in its context under ViolationsContextKey.
+func NewValidationError(message string, violations []Violation, context map[string]interface{}) error {
+	if context == nil {
+		context = make(map[string]interface{})
+	}
+	context[ViolationsContextKey] = violations
+	return NewInvalidParamsError(message, nil, context)
+}
+
+// ViolationsFrom extracts the validation violations attached to err, if any.
+func ViolationsFrom(err error) []Violation {
+	baseErr, ok := AsBaseError(err)
+	if !ok || baseErr.Context == nil {
+		return nil
+	}
+	violations, _ := baseErr.Context[ViolationsContextKey].([]Violation)
+	return violations
+}
+
 // --- JSON-RPC Error Mapping ---
 
 // MapAppErrorToJSONRPC translates an application error (or any error) into JSON-RPC components
@@ -207,9 +261,9 @@ func NewInvalidRequestError(message string, cause error, context map[string]inte
 // It attempts to extract code and context from BaseError types, and provides defaults for other errors.
 func MapAppErrorToJSONRPC(err error) (code int, message string, data map[string]interface{}) {
 	data = make(map[string]interface{}) // Initialize data map
-	var baseErr *BaseError
+	baseErr, ok := AsBaseError(err)
 
-	if !errors.As(err, &baseErr) {
+	if !ok {
 		// If the error is not an instance of BaseError, treat as a generic internal error.
 		code = int(ErrInternalError) // Default to JSONRPCInternalError value
 		message = "An internal server error occurred."
@@ -300,7 +354,7 @@ func MapAppErrorToJSONRPC(err error) (code int, message string, data map[string]
 			// Only include context fields deemed safe and useful for client exposure.
 			// Example: Allow 'uri', 'toolName', 'method' but not internal stack traces or sensitive details.
 			switch k {
-			case "uri", "toolName", "method", "serviceName", "parameter_name", "query_path", "requested_path": // Add more "safe" keys as needed
+			case "uri", "toolName", "method", "state", "serviceName", "parameter_name", "query_path", "requested_path", ViolationsContextKey: // Add more "safe" keys as needed
 				if _, exists := data[k]; !exists { // Avoid overwriting standard fields like 'detail' or 'internalCode'.
 					data[k] = v
 				}
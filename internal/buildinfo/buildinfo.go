@@ -1,12 +1,125 @@
 // Package buildinfo provides variables that store build-time information
 // such as version, commit hash, and build date. These variables are typically
-// populated by LDFLAGS during the Go build process.
+// populated by LDFLAGS during the Go build process. When they are not (e.g. after
+// a plain `go build` or `go install`), Get falls back to the module and VCS
+// metadata the Go toolchain embeds in the binary.
 // file: internal/buildinfo/buildinfo.go
 package buildinfo
 
+import (
+	"fmt"
+	"runtime"
+	"runtime/debug"
+	"strings"
+	"sync"
+)
+
 // These variables are populated by LDFLAGS during the build process
 var (
 	Version    string = "dev"
 	CommitHash string = "unknown"
 	BuildDate  string = "unknown"
 )
+
+// Defaults of the LDFLAGS variables, used to detect that they were not set.
+const (
+	defaultVersion = "dev"
+	unknown        = "unknown"
+)
+
+// Info describes the running binary.
+type Info struct {
+	Version   string `json:"version"`
+	Commit    string `json:"commit"`
+	BuildDate string `json:"buildDate"`
+	// Modified reports uncommitted changes in the working tree at build time
+	// (from VCS metadata only; LDFLAGS builds never set it).
+	Modified     bool         `json:"modified"`
+	GoVersion    string       `json:"goVersion"`
+	Module       string       `json:"module,omitempty"`
+	Dependencies []Dependency `json:"dependencies"`
+}
+
+// Dependency is a module compiled into the binary.
+type Dependency struct {
+	Path    string `json:"path"`
+	Version string `json:"version"`
+	// Replace is the replacement module path, if the dependency is replaced.
+	Replace string `json:"replace,omitempty"`
+}
+
+// readBuildInfo reads the embedded build metadata once; it cannot change at runtime.
+var readBuildInfo = sync.OnceValues(debug.ReadBuildInfo)
+
+// Get returns the build information. Values set through LDFLAGS take precedence;
+// any left at their defaults are filled from the embedded module and VCS metadata
+// (vcs.revision, vcs.time, vcs.modified and the main module version).
+func Get() Info {
+	info := Info{
+		Version:      Version,
+		Commit:       CommitHash,
+		BuildDate:    BuildDate,
+		GoVersion:    runtime.Version(),
+		Dependencies: []Dependency{},
+	}
+
+	bi, ok := readBuildInfo()
+	if !ok {
+		return info
+	}
+	info.Module = bi.Main.Path
+	if bi.GoVersion != "" {
+		info.GoVersion = bi.GoVersion
+	}
+
+	settings := make(map[string]string, len(bi.Settings))
+	for _, s := range bi.Settings {
+		settings[s.Key] = s.Value
+	}
+	if info.Version == defaultVersion && bi.Main.Version != "" && bi.Main.Version != "(devel)" {
+		info.Version = bi.Main.Version
+	}
+	if info.Commit == unknown && settings["vcs.revision"] != "" {
+		info.Commit = settings["vcs.revision"]
+		info.Modified = settings["vcs.modified"] == "true"
+	}
+	if info.BuildDate == unknown && settings["vcs.time"] != "" {
+		info.BuildDate = settings["vcs.time"]
+	}
+
+	for _, dep := range bi.Deps {
+		d := Dependency{Path: dep.Path, Version: dep.Version}
+		if dep.Replace != nil {
+			d.Replace = dep.Replace.Path
+			if dep.Replace.Version != "" {
+				d.Version = dep.Replace.Version
+			}
+		}
+		info.Dependencies = append(info.Dependencies, d)
+	}
+	return info
+}
+
+// String renders the information for humans, as printed by --version.
+func (i Info) String() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "version: %s\n", i.Version)
+	commit := i.Commit
+	if i.Modified {
+		commit += " (modified)"
+	}
+	fmt.Fprintf(&b, "commit:  %s\n", commit)
+	fmt.Fprintf(&b, "built:   %s\n", i.BuildDate)
+	fmt.Fprintf(&b, "go:      %s\n", i.GoVersion)
+	if i.Module != "" {
+		fmt.Fprintf(&b, "module:  %s\n", i.Module)
+	}
+	for _, d := range i.Dependencies {
+		if d.Replace != "" {
+			fmt.Fprintf(&b, "dep:     %s %s => %s\n", d.Path, d.Version, d.Replace)
+			continue
+		}
+		fmt.Fprintf(&b, "dep:     %s %s\n", d.Path, d.Version)
+	}
+	return b.String()
+}
@@ -0,0 +1,106 @@
+// file: internal/buildinfo/buildinfo_test.go
+package buildinfo
+
+import (
+	"runtime"
+	"runtime/debug"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestGet_PrefersLDFlags_When_VariablesAreSet (ADR-008 Naming)
+func TestGet_PrefersLDFlags_When_VariablesAreSet(t *testing.T) {
+	// Arrange
+	original := [3]string{Version, CommitHash, BuildDate}
+	t.Cleanup(func() { Version, CommitHash, BuildDate = original[0], original[1], original[2] })
+	Version, CommitHash, BuildDate = "v1.2.3", "abc123", "2025-01-02T03:04:05Z"
+
+	// Act
+	info := Get()
+
+	// Assert
+	assert.Equal(t, "v1.2.3", info.Version)
+	assert.Equal(t, "abc123", info.Commit)
+	assert.Equal(t, "2025-01-02T03:04:05Z", info.BuildDate)
+	assert.False(t, info.Modified, "modified is only known from VCS metadata")
+	assert.Equal(t, runtime.Version(), info.GoVersion)
+	assert.NotNil(t, info.Dependencies)
+}
+
+// stubBuildInfo makes Get read bi and ok instead of the binary's metadata, with the
+// LDFLAGS variables at their defaults.
+func stubBuildInfo(t *testing.T, bi *debug.BuildInfo, ok bool) {
+	t.Helper()
+	originalRead := readBuildInfo
+	original := [3]string{Version, CommitHash, BuildDate}
+	t.Cleanup(func() {
+		readBuildInfo = originalRead
+		Version, CommitHash, BuildDate = original[0], original[1], original[2]
+	})
+	readBuildInfo = func() (*debug.BuildInfo, bool) { return bi, ok }
+	Version, CommitHash, BuildDate = defaultVersion, unknown, unknown
+}
+
+// TestGet_FillsFromVCSMetadata_When_LDFlagsAreUnset (ADR-008 Naming)
+func TestGet_FillsFromVCSMetadata_When_LDFlagsAreUnset(t *testing.T) {
+	// Arrange
+	stubBuildInfo(t, &debug.BuildInfo{
+		GoVersion: "go1.99.0",
+		Main:      debug.Module{Path: "example.com/tool", Version: "v2.3.4"},
+		Deps: []*debug.Module{
+			{Path: "example.com/dep", Version: "v1.0.0", Replace: &debug.Module{Path: "../dep", Version: "v1.0.1"}},
+		},
+		Settings: []debug.BuildSetting{
+			{Key: "vcs.revision", Value: "0123456789abcdef"},
+			{Key: "vcs.time", Value: "2025-06-07T08:09:10Z"},
+			{Key: "vcs.modified", Value: "true"},
+		},
+	}, true)
+
+	// Act
+	info := Get()
+
+	// Assert
+	assert.Equal(t, "v2.3.4", info.Version)
+	assert.Equal(t, "0123456789abcdef", info.Commit)
+	assert.Equal(t, "2025-06-07T08:09:10Z", info.BuildDate)
+	assert.True(t, info.Modified)
+	assert.Equal(t, "go1.99.0", info.GoVersion)
+	assert.Equal(t, "example.com/tool", info.Module)
+	assert.Equal(t, []Dependency{{Path: "example.com/dep", Version: "v1.0.1", Replace: "../dep"}}, info.Dependencies)
+}
+
+// TestGet_KeepsDefaults_When_BuildInfoIsUnavailable (ADR-008 Naming)
+func TestGet_KeepsDefaults_When_BuildInfoIsUnavailable(t *testing.T) {
+	// Arrange
+	stubBuildInfo(t, nil, false)
+
+	// Act
+	info := Get()
+
+	// Assert
+	assert.Equal(t, defaultVersion, info.Version)
+	assert.Equal(t, unknown, info.Commit)
+	assert.Equal(t, unknown, info.BuildDate)
+	assert.False(t, info.Modified)
+	assert.Equal(t, runtime.Version(), info.GoVersion)
+	assert.Empty(t, info.Module)
+	assert.NotNil(t, info.Dependencies)
+}
+
+// TestInfoString_MarksModifiedCommit_When_TreeWasDirty (ADR-008 Naming)
+func TestInfoString_MarksModifiedCommit_When_TreeWasDirty(t *testing.T) {
+	// Arrange
+	info := Info{
+		Version: "v1.0.0", Commit: "abc", BuildDate: "today", Modified: true, GoVersion: "go1.24",
+		Dependencies: []Dependency{{Path: "example.com/a", Version: "v1.0.0", Replace: "../a"}},
+	}
+
+	// Act
+	out := info.String()
+
+	// Assert
+	assert.Contains(t, out, "commit:  abc (modified)\n")
+	assert.Contains(t, out, "dep:     example.com/a v1.0.0 => ../a\n")
+}
@@ -2,138 +2,189 @@
 // Package config handles loading, parsing, and validating application configuration.
 // It defines the structure for configuration settings, provides default values,
 // loads settings from files (e.g., YAML), and applies overrides from environment variables.
+// A Loader layers defaults, a base file, an environment overlay file, environment
+// variables and command-line flags, recording the source of every resolved field.
 // file: internal/config/config.go
 package config
 
 import (
-	"os"
-	"path/filepath"
-	"strconv"
+	"fmt"
+	"slices"
 	"time"
 
 	"github.com/cockroachdb/errors"
-	// Ensure this import path is correct for your hello-tool-base project structure
 	"github.com/dkoosis/hello-tool-base/internal/logging"
-	"gopkg.in/yaml.v3"
 )
 
 // ServerConfig contains settings specific to the server component.
+// The description tags document each field in the JSON Schema (see Schema).
 type ServerConfig struct {
-	Name            string        `yaml:"name"`
-	Port            int           `yaml:"port"`
-	ReadTimeout     time.Duration `yaml:"readTimeout"`
-	WriteTimeout    time.Duration `yaml:"writeTimeout"`
-	IdleTimeout     time.Duration `yaml:"idleTimeout"`
-	GracefulTimeout time.Duration `yaml:"gracefulTimeout"`
+	Name            string        `yaml:"name" env:"SERVER_NAME" description:"Human-readable service name, shown on / and reported to MCP clients."`
+	Port            int           `yaml:"port" env:"SERVER_PORT" description:"TCP port the HTTP server listens on." minimum:"1" maximum:"65535"`
+	ReadTimeout     time.Duration `yaml:"readTimeout" env:"SERVER_READ_TIMEOUT" description:"Maximum duration for reading an entire request, e.g. 15s."`
+	WriteTimeout    time.Duration `yaml:"writeTimeout" env:"SERVER_WRITE_TIMEOUT" description:"Maximum duration before timing out writes of a response, e.g. 15s."`
+	IdleTimeout     time.Duration `yaml:"idleTimeout" env:"SERVER_IDLE_TIMEOUT" description:"Maximum time to wait for the next request on a keep-alive connection."`
+	GracefulTimeout time.Duration `yaml:"gracefulTimeout" env:"SERVER_GRACEFUL_TIMEOUT" description:"Time allowed for in-flight requests on shutdown; at most Cloud Run's 10s termination window."`
+	// ResponseValidation controls checking of responses against openapi.yaml.
+	// Intended for development and test environments.
+	ResponseValidation ResponseValidationMode `yaml:"responseValidation" env:"SERVER_RESPONSE_VALIDATION" description:"Check responses against openapi.yaml: off, log violations, or strict to replace them with a 500." enum:"off,log,strict"`
+	// The identity headers are declared by clients, not authenticated; they label logs
+	// and key feature flags.
+	CallerHeader string `yaml:"callerHeader" env:"SERVER_CALLER_HEADER" description:"Request header identifying the calling agent, logged as caller and used by feature flags keyed on the caller attribute; empty to ignore."`
+	TenantHeader string `yaml:"tenantHeader" env:"SERVER_TENANT_HEADER" description:"Request header identifying the tenant a request acts for, logged as tenant; empty to ignore."`
 }
 
-// Config is the root configuration structure for the application.
-type Config struct {
-	Server ServerConfig `yaml:"server"`
-}
+// ResponseValidationMode selects how responses are checked against openapi.yaml.
+type ResponseValidationMode string
 
-// DefaultConfig returns a configuration populated with default values.
-func DefaultConfig() *Config {
-	cfg := &Config{
-		Server: ServerConfig{
-			Name:            "HelloToolBase Service",
-			Port:            8080,
-			ReadTimeout:     15 * time.Second,
-			WriteTimeout:    15 * time.Second,
-			IdleTimeout:     60 * time.Second,
-			GracefulTimeout: 15 * time.Second,
-		},
+// Response validation modes for ServerConfig.ResponseValidation.
+const (
+	ResponseValidationOff    ResponseValidationMode = "off"
+	ResponseValidationLog    ResponseValidationMode = "log"
+	ResponseValidationStrict ResponseValidationMode = "strict"
+)
+
+// UnmarshalText accepts only the defined modes, so typos fail in YAML, environment
+// variables and flags alike.
+func (m *ResponseValidationMode) UnmarshalText(text []byte) error {
+	switch mode := ResponseValidationMode(text); mode {
+	case ResponseValidationOff, ResponseValidationLog, ResponseValidationStrict:
+		*m = mode
+		return nil
+	default:
+		return errors.Newf("ResponseValidationMode.UnmarshalText: %q is not one of off, log, strict", text)
 	}
-	applyEnvironmentOverrides(cfg, logging.GetLogger("config_default"))
-	return cfg
 }
 
-// LoadFromFile loads configuration from the specified YAML file path.
-// It expands '~' to the user's home directory, reads the file, unmarshals YAML,
-// and applies environment variable overrides.
-func LoadFromFile(path string) (*Config, error) {
-	logger := logging.GetLogger("config_load")
-
-	if len(path) > 0 && path[0] == '~' {
-		homeDir, err := os.UserHomeDir()
-		if err != nil {
-			// Added function context to the error message
-			return nil, errors.Wrap(err, "LoadFromFile: failed to get home directory to expand path")
-		}
-		path = filepath.Join(homeDir, path[1:])
-		logger.Debug("Expanded config path from '~'", "originalPath", path, "expandedPath", path)
-	}
+// MCPConfig contains settings for serving the tools over the Model Context Protocol.
+type MCPConfig struct {
+	Enabled bool   `yaml:"enabled" env:"MCP_ENABLED" description:"Mount the streamable HTTP MCP endpoint at path alongside the REST routes."`
+	Path    string `yaml:"path" env:"MCP_PATH" description:"URL path of the MCP endpoint."`
+	// Stdio is for clients that launch the binary as a subprocess.
+	Stdio bool `yaml:"stdio" env:"MCP_STDIO" description:"Serve MCP over stdin/stdout instead of starting the HTTP server."`
+	// SessionIdleTimeout and MaxSessions bound the memory held by HTTP sessions
+	// that clients never DELETE.
+	SessionIdleTimeout time.Duration `yaml:"sessionIdleTimeout" env:"MCP_SESSION_IDLE_TIMEOUT" description:"How long an HTTP session may go unused before it expires, e.g. 30m."`
+	MaxSessions        int           `yaml:"maxSessions" env:"MCP_MAX_SESSIONS" description:"Most HTTP sessions kept open at once; opening another evicts the least recently used."`
+}
 
-	data, err := os.ReadFile(path)
-	if err != nil {
-		if os.IsNotExist(err) {
-			logger.Info("Configuration file not found, using defaults and environment variables.", "path", path)
-			config := DefaultConfig()
-			return config, nil
-		}
-		// Added function context to the error message
-		return nil, errors.Wrapf(err, "LoadFromFile: failed to read config file: %s", path)
+// LoggingConfig contains the settings of the application's logger.
+type LoggingConfig struct {
+	Level     string `yaml:"level" env:"LOG_LEVEL" description:"Minimum level of log records." enum:"debug,info,warn,error"`
+	Format    string `yaml:"format" env:"LOG_FORMAT" description:"Log record format; cloud is JSON in the Cloud Logging structured format, with severity and trace correlation." enum:"text,json,cloud"`
+	AddSource bool   `yaml:"addSource" env:"LOG_ADD_SOURCE" description:"Add the file and line of the logging call to each record."`
+	Output    string `yaml:"output" env:"LOG_OUTPUT" description:"Where logs are written: stderr, stdout or the path of a file to append to."`
+	ProjectID string `yaml:"projectId" env:"GOOGLE_CLOUD_PROJECT" description:"Google Cloud project used by the cloud format to link log entries to Cloud Trace."`
+	// Components are keyed by the name passed to logging.GetLogger, or a glob matching it.
+	Components map[string]string `yaml:"components" env:"LOG_COMPONENTS" description:"Minimum level per component name or glob pattern, overriding level for the matching loggers, e.g. mcp*: debug. The most specific match wins."`
+	// OverrideTTL bounds runtime overrides, which are not saved to the configuration.
+	OverrideTTL time.Duration `yaml:"overrideTtl" env:"LOG_OVERRIDE_TTL" description:"How long a level override set through /admin/log-levels or by SIGUSR1 lasts before reverting; also the longest TTL an admin request may ask for."`
+	// Redaction applies to every record, before any format handler sees it.
+	RedactKeys     []string `yaml:"redactKeys" env:"LOG_REDACT_KEYS" description:"Attribute keys whose values are masked, also as key=value pairs inside strings; a key matches itself and names ending in _<key>, in any case, so token also masks accessToken."`
+	RedactPatterns []string `yaml:"redactPatterns" env:"LOG_REDACT_PATTERNS" description:"Value patterns masked wherever they appear in messages and values: bearer, card (Luhn-valid card numbers), email, or a regular expression."`
+}
+
+// Options converts the settings into the options of logging.Setup.
+func (c LoggingConfig) Options() logging.Options {
+	return logging.Options{
+		Level:           c.Level,
+		Format:          c.Format,
+		AddSource:       c.AddSource,
+		Output:          c.Output,
+		ProjectID:       c.ProjectID,
+		ComponentLevels: c.Components,
+		RedactKeys:      c.RedactKeys,
+		RedactPatterns:  c.RedactPatterns,
 	}
-	logger.Info("Successfully read configuration file.", "path", path)
+}
 
-	config := DefaultConfig()
+// AdminConfig contains settings for the operator endpoints under /admin.
+type AdminConfig struct {
+	Token Secret `yaml:"token" env:"ADMIN_TOKEN" description:"Bearer token required by the /admin endpoints, which are not served when it is empty. May be a secret reference such as secret://file/run/secrets/admin_token or env://NAME."`
+}
 
-	if err := yaml.Unmarshal(data, config); err != nil {
-		// Added function context to the error message
-		return nil, errors.Wrapf(err, "LoadFromFile: failed to parse config file YAML: %s", path)
-	}
-	logger.Info("Successfully unmarshalled YAML from configuration file.", "path", path)
+// FeatureFlagsConfig configures the feature flags evaluated by the featureflags package.
+type FeatureFlagsConfig struct {
+	Flags map[string]FeatureFlag `yaml:"flags" description:"Feature flags by name."`
+}
 
-	applyEnvironmentOverrides(config, logger)
-	return config, nil
+// FeatureFlag is the rule of one feature flag. A disabled flag is off. An enabled flag
+// with neither Allow nor Percentage is on for everyone; otherwise it is on for the
+// attribute values in Allow and for Percentage percent of all other values. Percentage
+// is nil when unset, so an explicit 0 turns the flag off for all but Allow.
+type FeatureFlag struct {
+	Enabled    bool     `yaml:"enabled" description:"Turns the flag on; a disabled flag is off for every request."`
+	Percentage *float64 `yaml:"percentage" description:"Percentage of attribute values, chosen by a stable hash, for which an enabled flag is on." minimum:"0" maximum:"100"`
+	Attribute  string   `yaml:"attribute" description:"Request attribute a percentage rollout and the allow list are keyed on: traceId (default), caller, or header:<Name>."`
+	Allow      []string `yaml:"allow" description:"Attribute values for which an enabled flag is always on."`
 }
 
-// applyEnvironmentOverrides applies configuration overrides from environment variables.
-// It logs any overrides applied or any issues encountered during parsing of env vars.
-func applyEnvironmentOverrides(config *Config, logger logging.Logger) {
-	// Server Port
-	if portStr := os.Getenv("SERVER_PORT"); portStr != "" {
-		if port, err := strconv.Atoi(portStr); err == nil && port > 0 && port < 65536 { // err scoped to this if/else
-			logger.Debug("Overriding server port from environment.", "envVar", "SERVER_PORT", "oldValue", config.Server.Port, "newValue", port)
-			config.Server.Port = port
-		} else { // err is accessible here
-			logger.Warn("Invalid SERVER_PORT environment variable ignored.", "value", portStr, "error", err)
-		}
-	} else {
-		logger.Debug("No SERVER_PORT environment override found, using default/config file value.", "value", config.Server.Port)
+// String renders the rule for configuration reports, with the percentage only if set,
+// e.g. {enabled:true percentage:10 attribute:caller allow:[agent-a]}.
+func (f FeatureFlag) String() string {
+	percentage := ""
+	if f.Percentage != nil {
+		percentage = fmt.Sprintf(" percentage:%g", *f.Percentage)
 	}
+	return fmt.Sprintf("{enabled:%t%s attribute:%s allow:%v}", f.Enabled, percentage, f.Attribute, f.Allow)
+}
 
-	// Server Name
-	if serverName := os.Getenv("SERVER_NAME"); serverName != "" {
-		logger.Debug("Overriding server name from environment.", "envVar", "SERVER_NAME", "oldValue", config.Server.Name, "newValue", serverName)
-		config.Server.Name = serverName
-	} else {
-		logger.Debug("No SERVER_NAME environment override found, using default/config file value.", "value", config.Server.Name)
-	}
+// Config is the root configuration structure for the application.
+// Every setting except the feature flag rules can be overridden by the environment
+// variable named in its env tag; FeatureFlags is only read from configuration files.
+type Config struct {
+	Server ServerConfig `yaml:"server" description:"HTTP server settings."`
+	MCP    MCPConfig    `yaml:"mcp" description:"Model Context Protocol settings."`
+	Admin  AdminConfig  `yaml:"admin" description:"Operator endpoint settings."`
+	// Logging is applied once the configuration is loaded; until then main logs at info.
+	Logging LoggingConfig `yaml:"logging" description:"Logger settings."`
+	// FeatureFlags is read on every evaluation, so flag changes apply on reload.
+	FeatureFlags FeatureFlagsConfig `yaml:"featureFlags" description:"Feature flag rules."`
+}
 
-	// Helper for parsing duration from environment variable
-	getDurationEnv := func(envVar string, currentVal time.Duration, varNameHuman string) time.Duration {
-		envValStr := os.Getenv(envVar)
-		if envValStr != "" {
-			duration, err := time.ParseDuration(envValStr) // Declare duration and err here
-			if err == nil {                                // Check err
-				if duration != currentVal {
-					logger.Debug("Overriding "+varNameHuman+" from environment.", "envVar", envVar, "oldValue", currentVal, "newValue", duration)
-				} else {
-					logger.Debug("Environment variable "+envVar+" for "+varNameHuman+" matches current value.", "value", duration)
-				}
-				return duration
-			}
-			// Now 'err' is in scope for this Warn log
-			logger.Warn("Invalid "+envVar+" environment variable for "+varNameHuman+" ignored.", "value", envValStr, "error", err)
-		} else {
-			logger.Debug("No "+envVar+" environment override found for "+varNameHuman+", using current value.", "value", currentVal)
-		}
-		return currentVal
+// DefaultConfig returns a configuration populated with default values only;
+// environment variables are applied by the Loader.
+func DefaultConfig() *Config {
+	cfg := &Config{
+		Server: ServerConfig{
+			Name:               "HelloToolBase Service",
+			Port:               8080,
+			ReadTimeout:        15 * time.Second,
+			WriteTimeout:       15 * time.Second,
+			IdleTimeout:        60 * time.Second,
+			GracefulTimeout:    CloudRunTerminationWindow,
+			ResponseValidation: ResponseValidationOff,
+			CallerHeader:       "X-Caller-Id",
+			TenantHeader:       "X-Tenant-Id",
+		},
+		MCP: MCPConfig{
+			Enabled:            false,
+			Path:               "/mcp",
+			Stdio:              false,
+			SessionIdleTimeout: 30 * time.Minute,
+			MaxSessions:        1000,
+		},
+		Logging: LoggingConfig{
+			Level:  "info",
+			Format: logging.FormatText,
+			Output: logging.OutputStderr,
+			// Long enough to reproduce an issue, short enough not to be forgotten.
+			OverrideTTL:    15 * time.Minute,
+			RedactKeys:     slices.Clone(logging.DefaultRedactKeys),
+			RedactPatterns: slices.Clone(logging.DefaultRedactPatterns),
+		},
 	}
+	return cfg
+}
 
-	config.Server.ReadTimeout = getDurationEnv("SERVER_READ_TIMEOUT", config.Server.ReadTimeout, "server read timeout")
-	config.Server.WriteTimeout = getDurationEnv("SERVER_WRITE_TIMEOUT", config.Server.WriteTimeout, "server write timeout")
-	config.Server.IdleTimeout = getDurationEnv("SERVER_IDLE_TIMEOUT", config.Server.IdleTimeout, "server idle timeout")
-	config.Server.GracefulTimeout = getDurationEnv("SERVER_GRACEFUL_TIMEOUT", config.Server.GracefulTimeout, "server graceful timeout")
+// LoadFromFile loads configuration from the specified YAML file path.
+// It expands '~' to the user's home directory, reads the file (falling back to
+// defaults if it does not exist), unmarshals YAML, and applies environment variable
+// overrides. Use a Loader for overlay files, flags and value sources.
+func LoadFromFile(path string) (*Config, error) {
+	cfg, _, err := (&Loader{Path: path}).Load()
+	if err != nil {
+		return nil, errors.Wrap(err, "LoadFromFile: failed to load configuration")
+	}
+	return cfg, nil
 }
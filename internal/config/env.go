@@ -0,0 +1,64 @@
+// file: internal/config/env.go
+package config
+
+// env.go applies environment variable overrides named by the `env` struct tags.
+
+import (
+	"fmt"
+	"os"
+	"reflect"
+
+	"github.com/cockroachdb/errors"
+	"github.com/dkoosis/hello-tool-base/internal/logging"
+)
+
+// envName returns the environment variable that overrides the field, or "" if none.
+func (f field) envName() string {
+	return f.Struct.Tag.Get("env")
+}
+
+// applyEnvironmentOverrides sets every field whose `env` tag names a set environment
+// variable, recording the variable as the field's source. An empty value is applied
+// too: it clears strings, slices and maps, and is malformed for numbers, bools and
+// durations. Fields in
+// nested sections are reached through walkStruct, so any leaf type setFromString
+// understands can be overridden: strings, numbers, bools, durations, slices, maps
+// and encoding.TextUnmarshaler implementations.
+//
+// A malformed value is logged and ignored, unless strict is set, in which case the
+// first one is returned as an error naming the variable. Values of secret fields
+// are never logged.
+func applyEnvironmentOverrides(cfg *Config, logger logging.Logger, sources Sources, strict bool) error {
+	return applyEnv(reflect.ValueOf(cfg).Elem(), logger, sources, strict)
+}
+
+// applyEnv applies the `env` tags of the struct v; see applyEnvironmentOverrides.
+func applyEnv(v reflect.Value, logger logging.Logger, sources Sources, strict bool) error {
+	var firstErr error
+	walkStruct(v, "", func(f field) {
+		envVar := f.envName()
+		if envVar == "" || firstErr != nil {
+			return
+		}
+		raw, ok := os.LookupEnv(envVar)
+		if !ok {
+			return
+		}
+
+		value := any(raw)
+		if f.secret() {
+			value = Redacted
+		}
+		if err := setFromString(f.Value, raw); err != nil {
+			if strict {
+				firstErr = errors.Wrapf(err, "applyEnvironmentOverrides: invalid value for %s", envVar)
+				return
+			}
+			logger.Warn("Invalid environment variable ignored.", "envVar", envVar, "path", f.Path, "value", value, "error", fmt.Sprintf("%+v", err))
+			return
+		}
+		logger.Debug("Overriding configuration from environment.", "envVar", envVar, "path", f.Path, "value", value)
+		sources.set(f.Path, LayerEnv, envVar)
+	})
+	return firstErr
+}
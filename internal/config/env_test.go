@@ -0,0 +1,135 @@
+// file: internal/config/env_test.go
+package config
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/cockroachdb/errors"
+	"github.com/dkoosis/hello-tool-base/internal/logging"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// level is a TextUnmarshaler used to exercise custom env types.
+type level int
+
+func (l *level) UnmarshalText(text []byte) error {
+	switch strings.ToLower(string(text)) {
+	case "low":
+		*l = 1
+	case "high":
+		*l = 2
+	default:
+		return errors.Newf("level.UnmarshalText: unknown level %q", text)
+	}
+	return nil
+}
+
+type envTestConfig struct {
+	Nested struct {
+		Timeout time.Duration `yaml:"timeout" env:"TEST_NESTED_TIMEOUT"`
+		Debug   bool          `yaml:"debug" env:"TEST_NESTED_DEBUG"`
+	} `yaml:"nested"`
+	Hosts    []string       `yaml:"hosts" env:"TEST_HOSTS"`
+	Ports    []int          `yaml:"ports" env:"TEST_PORTS"`
+	Weights  map[string]int `yaml:"weights" env:"TEST_WEIGHTS"`
+	Level    level          `yaml:"level" env:"TEST_LEVEL"`
+	Untagged string         `yaml:"untagged"`
+}
+
+// TestApplyEnv_SetsTaggedFields_When_VariablesHaveSupportedTypes (ADR-008 Naming)
+func TestApplyEnv_SetsTaggedFields_When_VariablesHaveSupportedTypes(t *testing.T) {
+	// Arrange
+	t.Setenv("TEST_NESTED_TIMEOUT", "3s")
+	t.Setenv("TEST_NESTED_DEBUG", "true")
+	t.Setenv("TEST_HOSTS", "a.example, b.example")
+	t.Setenv("TEST_PORTS", "80,443")
+	t.Setenv("TEST_WEIGHTS", "a=1, b=2")
+	t.Setenv("TEST_LEVEL", "high")
+	var cfg envTestConfig
+	sources := Sources{}
+
+	// Act
+	err := applyEnv(reflect.ValueOf(&cfg).Elem(), logging.GetLogger("test"), sources, true)
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, 3*time.Second, cfg.Nested.Timeout)
+	assert.True(t, cfg.Nested.Debug)
+	assert.Equal(t, []string{"a.example", "b.example"}, cfg.Hosts)
+	assert.Equal(t, []int{80, 443}, cfg.Ports)
+	assert.Equal(t, map[string]int{"a": 1, "b": 2}, cfg.Weights)
+	assert.Equal(t, level(2), cfg.Level)
+	assert.Equal(t, Source{Layer: LayerEnv, Name: "TEST_NESTED_TIMEOUT"}, sources["nested.timeout"])
+	assert.NotContains(t, sources, "untagged")
+}
+
+// TestApplyEnv_ClearsField_When_VariableIsSetButEmpty (ADR-008 Naming)
+func TestApplyEnv_ClearsField_When_VariableIsSetButEmpty(t *testing.T) {
+	// Arrange
+	t.Setenv("TEST_HOSTS", "")
+	t.Setenv("TEST_WEIGHTS", "")
+	var cfg envTestConfig
+	cfg.Hosts = []string{"default.example"}
+	cfg.Weights = map[string]int{"a": 1}
+	cfg.Nested.Timeout = time.Second
+	sources := Sources{}
+
+	// Act
+	err := applyEnv(reflect.ValueOf(&cfg).Elem(), logging.GetLogger("test"), sources, true)
+
+	// Assert
+	require.NoError(t, err)
+	assert.Nil(t, cfg.Hosts)
+	assert.Empty(t, cfg.Weights)
+	assert.Equal(t, time.Second, cfg.Nested.Timeout, "unset variables must not be applied")
+	assert.Equal(t, Source{Layer: LayerEnv, Name: "TEST_HOSTS"}, sources["hosts"])
+}
+
+// TestApplyEnv_ReturnsError_When_StrictAndDurationIsSetButEmpty (ADR-008 Naming)
+func TestApplyEnv_ReturnsError_When_StrictAndDurationIsSetButEmpty(t *testing.T) {
+	// Arrange
+	t.Setenv("TEST_NESTED_TIMEOUT", "")
+	var cfg envTestConfig
+
+	// Act
+	err := applyEnv(reflect.ValueOf(&cfg).Elem(), logging.GetLogger("test"), Sources{}, true)
+
+	// Assert
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "TEST_NESTED_TIMEOUT")
+}
+
+// TestApplyEnvironmentOverrides_ReturnsErrorNamingVariable_When_StrictAndValueMalformed (ADR-008 Naming)
+func TestApplyEnvironmentOverrides_ReturnsErrorNamingVariable_When_StrictAndValueMalformed(t *testing.T) {
+	// Arrange
+	t.Setenv("SERVER_READ_TIMEOUT", "soon")
+	cfg := DefaultConfig()
+
+	// Act
+	strictErr := applyEnvironmentOverrides(cfg, logging.GetLogger("test"), Sources{}, true)
+	lenientErr := applyEnvironmentOverrides(cfg, logging.GetLogger("test"), Sources{}, false)
+
+	// Assert
+	require.Error(t, strictErr)
+	assert.Contains(t, strictErr.Error(), "SERVER_READ_TIMEOUT")
+	assert.NoError(t, lenientErr)
+	assert.Equal(t, 15*time.Second, cfg.Server.ReadTimeout, "malformed value must not be applied")
+}
+
+// TestLoader_Fails_When_StrictEnvAndModeUnknown (ADR-008 Naming)
+func TestLoader_Fails_When_StrictEnvAndModeUnknown(t *testing.T) {
+	// Arrange
+	t.Setenv("SERVER_RESPONSE_VALIDATION", "strcit")
+	loader := &Loader{StrictEnv: true}
+
+	// Act
+	_, _, err := loader.Load()
+
+	// Assert
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "SERVER_RESPONSE_VALIDATION")
+}
@@ -0,0 +1,217 @@
+// file: internal/config/fields.go
+package config
+
+// fields.go walks configuration fields by their dotted YAML path and sets them from strings.
+
+import (
+	"encoding"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/cockroachdb/errors"
+)
+
+// field is a leaf of the configuration tree.
+type field struct {
+	// Path is the dotted YAML path, e.g. "server.readTimeout".
+	Path   string
+	Value  reflect.Value
+	Struct reflect.StructField
+}
+
+// secret reports whether the field holds a credential that must not be displayed:
+// it is a Secret or is tagged `secret:"true"`.
+func (f field) secret() bool {
+	return f.Value.Type() == secretType || f.Struct.Tag.Get("secret") == "true"
+}
+
+var (
+	durationType        = reflect.TypeOf(time.Duration(0))
+	textUnmarshalerType = reflect.TypeOf((*encoding.TextUnmarshaler)(nil)).Elem()
+)
+
+// yamlName returns the YAML key of a struct field, or "" if the field is not mapped.
+func yamlName(sf reflect.StructField) string {
+	if !sf.IsExported() {
+		return ""
+	}
+	name, _, _ := strings.Cut(sf.Tag.Get("yaml"), ",")
+	switch name {
+	case "-":
+		return ""
+	case "":
+		return strings.ToLower(sf.Name)
+	}
+	return name
+}
+
+// isSection reports whether a value of type t is a nested section rather than a leaf.
+// Structs that parse themselves from text are leaves.
+func isSection(t reflect.Type) bool {
+	return t.Kind() == reflect.Struct && !reflect.PointerTo(t).Implements(textUnmarshalerType)
+}
+
+// walkFields calls fn for every leaf field of cfg in declaration order.
+func walkFields(cfg *Config, fn func(f field)) {
+	walkStruct(reflect.ValueOf(cfg).Elem(), "", fn)
+}
+
+func walkStruct(v reflect.Value, prefix string, fn func(f field)) {
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		sf := t.Field(i)
+		name := yamlName(sf)
+		if name == "" {
+			continue
+		}
+		path := name
+		if prefix != "" {
+			path = prefix + "." + name
+		}
+		if isSection(sf.Type) {
+			walkStruct(v.Field(i), path, fn)
+			continue
+		}
+		fn(field{Path: path, Value: v.Field(i), Struct: sf})
+	}
+}
+
+// fieldPaths returns the paths of all leaf fields, sorted.
+func fieldPaths() []string {
+	var paths []string
+	walkFields(&Config{}, func(f field) { paths = append(paths, f.Path) })
+	sort.Strings(paths)
+	return paths
+}
+
+// lookupField finds the leaf field of cfg at path.
+func lookupField(cfg *Config, path string) (field, bool) {
+	var found field
+	ok := false
+	walkFields(cfg, func(f field) {
+		if f.Path == path {
+			found, ok = f, true
+		}
+	})
+	return found, ok
+}
+
+// parsable reports whether setFromString can set a value of type t.
+func parsable(t reflect.Type) bool {
+	if reflect.PointerTo(t).Implements(textUnmarshalerType) || t == durationType {
+		return true
+	}
+	switch t.Kind() {
+	case reflect.String, reflect.Bool,
+		reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		return true
+	case reflect.Slice:
+		return parsable(t.Elem())
+	case reflect.Map:
+		return t.Key().Kind() == reflect.String && parsable(t.Elem())
+	}
+	return false
+}
+
+// setFromString parses raw into v according to v's type. Types implementing
+// encoding.TextUnmarshaler parse themselves; slices take comma-separated elements
+// and maps with string keys take comma-separated key=value pairs.
+func setFromString(v reflect.Value, raw string) error {
+	if v.CanAddr() && v.Addr().Type().Implements(textUnmarshalerType) {
+		if err := v.Addr().Interface().(encoding.TextUnmarshaler).UnmarshalText([]byte(raw)); err != nil {
+			return errors.Wrapf(err, "setFromString: %q is not a valid %s", raw, v.Type())
+		}
+		return nil
+	}
+	if v.Type() == durationType {
+		d, err := time.ParseDuration(raw)
+		if err != nil {
+			return errors.Wrapf(err, "setFromString: %q is not a duration", raw)
+		}
+		v.SetInt(int64(d))
+		return nil
+	}
+	switch v.Kind() {
+	case reflect.String:
+		v.SetString(raw)
+	case reflect.Bool:
+		b, err := strconv.ParseBool(raw)
+		if err != nil {
+			return errors.Wrapf(err, "setFromString: %q is not a boolean", raw)
+		}
+		v.SetBool(b)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n, err := strconv.ParseInt(raw, 10, v.Type().Bits())
+		if err != nil {
+			return errors.Wrapf(err, "setFromString: %q is not an integer", raw)
+		}
+		v.SetInt(n)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		n, err := strconv.ParseUint(raw, 10, v.Type().Bits())
+		if err != nil {
+			return errors.Wrapf(err, "setFromString: %q is not an unsigned integer", raw)
+		}
+		v.SetUint(n)
+	case reflect.Float32, reflect.Float64:
+		n, err := strconv.ParseFloat(raw, v.Type().Bits())
+		if err != nil {
+			return errors.Wrapf(err, "setFromString: %q is not a number", raw)
+		}
+		v.SetFloat(n)
+	case reflect.Slice:
+		return setSliceFromString(v, raw)
+	case reflect.Map:
+		return setMapFromString(v, raw)
+	default:
+		return errors.Newf("setFromString: unsupported field type %s", v.Type())
+	}
+	return nil
+}
+
+// setSliceFromString replaces the slice v with the comma-separated elements of raw.
+// An empty string gives a nil slice.
+func setSliceFromString(v reflect.Value, raw string) error {
+	if raw == "" {
+		v.Set(reflect.Zero(v.Type()))
+		return nil
+	}
+	parts := strings.Split(raw, ",")
+	slice := reflect.MakeSlice(v.Type(), len(parts), len(parts))
+	for i, part := range parts {
+		if err := setFromString(slice.Index(i), strings.TrimSpace(part)); err != nil {
+			return errors.Wrapf(err, "setSliceFromString: element %d", i)
+		}
+	}
+	v.Set(slice)
+	return nil
+}
+
+// setMapFromString replaces the map v with the comma-separated key=value pairs of raw.
+// An empty string gives an empty map.
+func setMapFromString(v reflect.Value, raw string) error {
+	if v.Type().Key().Kind() != reflect.String {
+		return errors.Newf("setMapFromString: unsupported map key type %s", v.Type().Key())
+	}
+	m := reflect.MakeMap(v.Type())
+	if raw != "" {
+		for _, pair := range strings.Split(raw, ",") {
+			key, value, ok := strings.Cut(pair, "=")
+			key = strings.TrimSpace(key)
+			if !ok || key == "" {
+				return errors.Newf("setMapFromString: %q is not a key=value pair", pair)
+			}
+			elem := reflect.New(v.Type().Elem()).Elem()
+			if err := setFromString(elem, strings.TrimSpace(value)); err != nil {
+				return errors.Wrapf(err, "setMapFromString: key %q", key)
+			}
+			m.SetMapIndex(reflect.ValueOf(key).Convert(v.Type().Key()), elem)
+		}
+	}
+	v.Set(m)
+	return nil
+}
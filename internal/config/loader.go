@@ -0,0 +1,318 @@
+// file: internal/config/loader.go
+package config
+
+// loader.go resolves configuration from layered sources and records where each value came from.
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/cockroachdb/errors"
+	"github.com/dkoosis/hello-tool-base/internal/logging"
+	"gopkg.in/yaml.v3"
+)
+
+// Layer identifies a configuration source, in increasing order of precedence.
+type Layer string
+
+// Configuration layers, lowest precedence first.
+const (
+	LayerDefault Layer = "default"
+	LayerFile    Layer = "file"
+	LayerOverlay Layer = "overlay"
+	LayerEnv     Layer = "env"
+	LayerFlag    Layer = "flag"
+)
+
+// Source records where a resolved value came from: the layer and, for files,
+// environment variables and flags, which one.
+type Source struct {
+	Layer Layer
+	Name  string
+}
+
+// String renders the source, e.g. "env SERVER_PORT" or "file config.yaml".
+func (s Source) String() string {
+	if s.Name == "" {
+		return string(s.Layer)
+	}
+	return string(s.Layer) + " " + s.Name
+}
+
+// MarshalText renders the source as its string form in JSON and YAML.
+func (s Source) MarshalText() ([]byte, error) {
+	return []byte(s.String()), nil
+}
+
+// Sources maps each field path (e.g. "server.port") to the source of its value.
+type Sources map[string]Source
+
+// set records the source of path.
+func (s Sources) set(path string, layer Layer, name string) {
+	s[path] = Source{Layer: layer, Name: name}
+}
+
+// Loader resolves configuration from layered sources, each overriding the previous:
+// defaults, the base YAML file, an environment-specific overlay file, environment
+// variables and command-line flags. It records the source of every field.
+type Loader struct {
+	// Path is the base YAML file. A leading '~' is expanded; a missing file is skipped.
+	Path string
+	// Environment selects an overlay file next to Path named after it, e.g.
+	// "production" loads config.production.yaml for config.yaml. Empty disables
+	// the overlay; a missing overlay file is skipped.
+	Environment string
+	// Flags holds command-line values by field path, as collected by RegisterFlags.
+	Flags map[string]string
+	// StrictEnv makes a malformed environment variable fail Load instead of being
+	// logged and ignored.
+	StrictEnv bool
+	// SecretProviders resolves Secret references by provider name, in addition to
+	// (or replacing) DefaultSecretProviders.
+	SecretProviders map[string]SecretProvider
+}
+
+// Load resolves the configuration and the source of each field, then validates it.
+func (l *Loader) Load() (*Config, Sources, error) {
+	cfg, sources, err := l.resolve()
+	if err != nil {
+		return nil, nil, err
+	}
+	if err := cfg.Validate(); err != nil {
+		return nil, nil, err
+	}
+	return cfg, sources, nil
+}
+
+// resolve applies every layer and resolves secret references, without validating the result.
+func (l *Loader) resolve() (*Config, Sources, error) {
+	logger := logging.GetLogger("config_load")
+
+	cfg := DefaultConfig()
+	sources := make(Sources)
+	walkFields(cfg, func(f field) { sources.set(f.Path, LayerDefault, "") })
+
+	doc, err := l.mergeFiles(logger)
+	if err != nil {
+		return nil, nil, err
+	}
+	if doc.root != nil {
+		if err := doc.root.Decode(cfg); err != nil {
+			return nil, nil, errors.Wrap(err, "resolve: failed to decode merged configuration files")
+		}
+		recordNodeSources(doc.root, reflect.TypeOf(*cfg), "", sources, doc)
+	}
+
+	if err := applyEnvironmentOverrides(cfg, logger, sources, l.StrictEnv); err != nil {
+		return nil, nil, err
+	}
+
+	if err := applyFlags(cfg, sources, l.Flags); err != nil {
+		return nil, nil, err
+	}
+
+	providers := DefaultSecretProviders()
+	for name, p := range l.SecretProviders {
+		providers[name] = p
+	}
+	if err := resolveSecrets(context.Background(), cfg, providers); err != nil {
+		return nil, nil, err
+	}
+	return cfg, sources, nil
+}
+
+// files returns the configuration files the loader reads, whether or not they exist.
+func (l *Loader) files() ([]string, error) {
+	if l.Path == "" {
+		return nil, nil
+	}
+	path, err := expandHome(l.Path)
+	if err != nil {
+		return nil, err
+	}
+	files := []string{path}
+	if l.Environment != "" {
+		files = append(files, overlayPath(path, l.Environment))
+	}
+	return files, nil
+}
+
+// watchedFiles returns the files whose changes affect the configuration: the base and
+// overlay files, whether or not they exist, the conf.d fragments and every included file.
+func (l *Loader) watchedFiles() []string {
+	files, err := l.files()
+	if err != nil {
+		return nil
+	}
+	if len(files) > 0 {
+		fragments, _ := confDirFragments(filepath.Join(filepath.Dir(files[0]), confDir))
+		files = append(files, fragments...)
+	}
+	if doc, err := l.mergeFiles(logging.GetNoopLogger()); err == nil {
+		files = append(files, doc.files...)
+	}
+	return files
+}
+
+// Dump writes the merged configuration files as YAML, each value commented with the
+// file it came from. Environment variables and flags are not included; see Report.
+func (l *Loader) Dump(w io.Writer) error {
+	doc, err := l.mergeFiles(logging.GetNoopLogger())
+	if err != nil {
+		return err
+	}
+	if doc.root == nil {
+		return nil
+	}
+	annotateOrigins(doc.root, doc.origins)
+	enc := yaml.NewEncoder(w)
+	enc.SetIndent(2)
+	if err := enc.Encode(doc.root); err != nil {
+		return errors.Wrap(err, "Loader.Dump: failed to encode merged configuration")
+	}
+	return enc.Close()
+}
+
+// annotateOrigins sets the line comment of every value beneath a mapping to its origin.
+func annotateOrigins(node *yaml.Node, origins map[*yaml.Node]Source) {
+	if node.Kind != yaml.MappingNode {
+		return
+	}
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		key, value := node.Content[i], node.Content[i+1]
+		if value.Kind == yaml.MappingNode {
+			annotateOrigins(value, origins)
+			continue
+		}
+		key.LineComment = origins[value].String()
+	}
+}
+
+// expandHome expands a leading '~' to the user's home directory.
+func expandHome(path string) (string, error) {
+	if len(path) == 0 || path[0] != '~' {
+		return path, nil
+	}
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		return "", errors.Wrap(err, "expandHome: failed to get home directory to expand path")
+	}
+	expanded := filepath.Join(homeDir, path[1:])
+	logging.GetLogger("config_load").Debug("Expanded config path from '~'", "originalPath", path, "expandedPath", expanded)
+	return expanded, nil
+}
+
+// overlayPath names the overlay file for environment next to the base file,
+// e.g. config.yaml and "production" give config.production.yaml.
+func overlayPath(base, environment string) string {
+	ext := filepath.Ext(base)
+	return strings.TrimSuffix(base, ext) + "." + environment + ext
+}
+
+// mergeFiles merges the base file, its conf.d fragments and the overlay file, each
+// after the files it includes. Missing base and overlay files are skipped. Every file
+// is decoded strictly: unknown keys, duplicate keys and mistyped values are all
+// reported, with their line and column, as DecodeErrors.
+func (l *Loader) mergeFiles(logger logging.Logger) (*document, error) {
+	doc := newDocument(logger)
+	files, err := l.files()
+	if err != nil {
+		return nil, err
+	}
+	for i, path := range files {
+		layer := LayerFile
+		if i > 0 {
+			layer = LayerOverlay
+		}
+		if err := doc.mergeFile(path, layer, false, nil); err != nil {
+			return nil, errors.Wrap(err, "mergeFiles: invalid config file")
+		}
+		if layer == LayerFile {
+			if err := doc.mergeConfDir(path, layer); err != nil {
+				return nil, errors.Wrap(err, "mergeFiles: invalid config file")
+			}
+		}
+	}
+	return doc, nil
+}
+
+// recordNodeSources records, for every field set by the YAML mapping node, the file it
+// came from. A map merged from several files is credited to the last file that set
+// one of its entries.
+func recordNodeSources(node *yaml.Node, t reflect.Type, prefix string, sources Sources, doc *document) {
+	if node.Kind != yaml.MappingNode {
+		return
+	}
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		key, value := node.Content[i].Value, node.Content[i+1]
+		for j := 0; j < t.NumField(); j++ {
+			sf := t.Field(j)
+			if yamlName(sf) != key {
+				continue
+			}
+			path := key
+			if prefix != "" {
+				path = prefix + "." + key
+			}
+			if isSection(sf.Type) {
+				recordNodeSources(value, sf.Type, path, sources, doc)
+			} else {
+				sources[path] = doc.latestOrigin(value)
+			}
+		}
+	}
+}
+
+// RegisterFlags defines a command-line flag for every configuration field that can be
+// parsed from a string, named by its path (e.g. -server.port). The returned map is
+// filled, during fs.Parse, with the flags actually given; pass it as Loader.Flags.
+func RegisterFlags(fs *flag.FlagSet) map[string]string {
+	values := make(map[string]string)
+	defaults := DefaultConfig()
+	walkFields(defaults, func(f field) {
+		if !parsable(f.Value.Type()) {
+			return
+		}
+		path := f.Path
+		usage := fmt.Sprintf("override %s (default %v)", path, f.Value.Interface())
+		if f.secret() {
+			usage = "override " + path
+		}
+		fs.Func(path, usage, func(raw string) error {
+			probe := reflect.New(f.Value.Type()).Elem()
+			if err := setFromString(probe, raw); err != nil {
+				return err
+			}
+			values[path] = raw
+			return nil
+		})
+	})
+	return values
+}
+
+// applyFlags sets the fields given on the command line.
+func applyFlags(cfg *Config, sources Sources, flags map[string]string) error {
+	paths := make([]string, 0, len(flags))
+	for path := range flags {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+	for _, path := range paths {
+		f, ok := lookupField(cfg, path)
+		if !ok {
+			return errors.Newf("applyFlags: unknown configuration field %q", path)
+		}
+		if err := setFromString(f.Value, flags[path]); err != nil {
+			return errors.Wrapf(err, "applyFlags: invalid value for flag -%s", path)
+		}
+		sources.set(path, LayerFlag, "-"+path)
+	}
+	return nil
+}
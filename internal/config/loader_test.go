@@ -0,0 +1,82 @@
+// file: internal/config/loader_test.go
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// writeFile writes content to name in dir and returns its path.
+func writeFile(t *testing.T, dir, name, content string) string {
+	t.Helper()
+	path := filepath.Join(dir, name)
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+	return path
+}
+
+// TestLoader_RecordsSourceOfEachField_When_AllLayersSetValues (ADR-008 Naming)
+func TestLoader_RecordsSourceOfEachField_When_AllLayersSetValues(t *testing.T) {
+	// Arrange
+	dir := t.TempDir()
+	base := writeFile(t, dir, "config.yaml", "server:\n  port: 9000\n  readTimeout: 20s\n  writeTimeout: 21s\n")
+	overlay := writeFile(t, dir, "config.production.yaml", "server:\n  writeTimeout: 30s\n  idleTimeout: 31s\n")
+	t.Setenv("SERVER_PORT", "9100")
+	t.Setenv("SERVER_IDLE_TIMEOUT", "40s")
+	loader := &Loader{Path: base, Environment: "production", Flags: map[string]string{"server.idleTimeout": "50s"}}
+
+	// Act
+	cfg, sources, err := loader.Load()
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, 20*time.Second, cfg.Server.ReadTimeout)
+	assert.Equal(t, 30*time.Second, cfg.Server.WriteTimeout)
+	assert.Equal(t, 50*time.Second, cfg.Server.IdleTimeout)
+	assert.Equal(t, 9100, cfg.Server.Port)
+	assert.Equal(t, Source{Layer: LayerFile, Name: base}, sources["server.readTimeout"])
+	assert.Equal(t, Source{Layer: LayerOverlay, Name: overlay}, sources["server.writeTimeout"])
+	assert.Equal(t, Source{Layer: LayerEnv, Name: "SERVER_PORT"}, sources["server.port"])
+	assert.Equal(t, Source{Layer: LayerFlag, Name: "-server.idleTimeout"}, sources["server.idleTimeout"])
+	assert.Equal(t, Source{Layer: LayerDefault}, sources["server.gracefulTimeout"])
+	assert.Len(t, sources, len(fieldPaths()), "every field must have a source")
+}
+
+// TestLoader_CreditsMapToLastFile_When_MapMergedFromSeveralFiles (ADR-008 Naming)
+func TestLoader_CreditsMapToLastFile_When_MapMergedFromSeveralFiles(t *testing.T) {
+	// Arrange
+	dir := t.TempDir()
+	base := writeFile(t, dir, "config.yaml", "logging:\n  components:\n    mcp: info\n")
+	overlay := writeFile(t, dir, "config.production.yaml", "logging:\n  components:\n    http: debug\n")
+	loader := &Loader{Path: base, Environment: "production"}
+
+	// Act
+	cfg, sources, err := loader.Load()
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"mcp": "info", "http": "debug"}, cfg.Logging.Components)
+	assert.Equal(t, Source{Layer: LayerOverlay, Name: overlay}, sources["logging.components"])
+}
+
+// TestReport_RedactsSecret_When_TokenIsSet (ADR-008 Naming)
+func TestReport_RedactsSecret_When_TokenIsSet(t *testing.T) {
+	// Arrange
+	cfg := DefaultConfig()
+	cfg.Admin.Token = NewSecret("s3cret")
+	var out strings.Builder
+
+	// Act
+	err := WriteReport(&out, Report(cfg, Sources{}))
+
+	// Assert
+	require.NoError(t, err)
+	assert.NotContains(t, out.String(), "s3cret")
+	assert.Contains(t, out.String(), "admin.token")
+	assert.Contains(t, out.String(), Redacted)
+}
@@ -0,0 +1,270 @@
+// file: internal/config/merge.go
+package config
+
+// merge.go combines configuration files, their includes and conf.d fragments into one
+// YAML document, remembering which file each value came from.
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/cockroachdb/errors"
+	"github.com/dkoosis/hello-tool-base/internal/logging"
+	"gopkg.in/yaml.v3"
+)
+
+// includeKey is the top-level key listing files to merge beneath the file that names
+// them. Paths are relative to that file and may be glob patterns.
+const includeKey = "include"
+
+// confDir is the directory, next to the base file, whose *.yaml and *.yml fragments
+// are merged over the base file in lexical order.
+const confDir = "conf.d"
+
+// Override markers are YAML tags on a value that change how it merges over the value
+// from earlier files. Without a marker, mappings merge key by key and everything
+// else, including sequences, replaces the earlier value.
+const (
+	// markerReplace on a mapping replaces the earlier mapping instead of merging into it.
+	markerReplace = "!replace"
+	// markerAppend on a sequence appends its items to the earlier sequence.
+	markerAppend = "!append"
+)
+
+// document is the merge of several configuration files.
+type document struct {
+	root *yaml.Node
+	// origins records the file each node was read from.
+	origins map[*yaml.Node]Source
+	// files lists every file read, in the order read.
+	files []string
+	// merged lists the files merged into root, in merge order: a file's includes
+	// come before it.
+	merged []string
+	logger logging.Logger
+}
+
+func newDocument(logger logging.Logger) *document {
+	return &document{origins: make(map[*yaml.Node]Source), logger: logger}
+}
+
+// mergeFile merges the file at path into d: first the files it includes, then the
+// file itself. A missing file is an error when required, and skipped otherwise.
+// stack holds the including files, to detect cycles.
+func (d *document) mergeFile(path string, layer Layer, required bool, stack []string) error {
+	abs, err := filepath.Abs(path)
+	if err != nil {
+		return errors.Wrapf(err, "mergeFile: failed to resolve path: %s", path)
+	}
+	if slices.Contains(stack, abs) {
+		return errors.Newf("mergeFile: include cycle: %s is included by itself via %v", path, stack)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) && !required {
+			d.logger.Info("Configuration file not found, skipping.", "path", path, "layer", layer)
+			return nil
+		}
+		return errors.Wrapf(err, "mergeFile: failed to read config file: %s", path)
+	}
+	d.logger.Info("Successfully read configuration file.", "path", path, "layer", layer)
+	d.files = append(d.files, path)
+
+	var doc yaml.Node
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return errors.Wrapf(err, "mergeFile: failed to parse config file YAML: %s", path)
+	}
+	if len(doc.Content) == 0 {
+		return nil // Empty file.
+	}
+	root := doc.Content[0]
+	includes, err := takeIncludes(root, path)
+	if err != nil {
+		return err
+	}
+	markers, err := takeMarkers(root, path)
+	if err != nil {
+		return err
+	}
+	if err := checkDocument(root, path); err != nil {
+		return err
+	}
+
+	for _, include := range includes {
+		if err := d.mergeFile(include, layer, true, append(stack, abs)); err != nil {
+			return err
+		}
+	}
+	d.setOrigin(root, Source{Layer: layer, Name: path})
+	d.root = d.merge(d.root, root, markers)
+	d.merged = append(d.merged, path)
+	return nil
+}
+
+// mergeConfDir merges the fragments in the conf.d directory next to base, in lexical order.
+func (d *document) mergeConfDir(base string, layer Layer) error {
+	dir := filepath.Join(filepath.Dir(base), confDir)
+	fragments, err := confDirFragments(dir)
+	if err != nil {
+		return err
+	}
+	for _, fragment := range fragments {
+		if err := d.mergeFile(fragment, layer, true, nil); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// confDirFragments lists the YAML files in dir in lexical order. A missing dir has none.
+func confDirFragments(dir string) ([]string, error) {
+	var fragments []string
+	for _, pattern := range []string{"*.yaml", "*.yml"} {
+		matches, err := filepath.Glob(filepath.Join(dir, pattern))
+		if err != nil {
+			return nil, errors.Wrapf(err, "confDirFragments: invalid pattern in %s", dir)
+		}
+		fragments = append(fragments, matches...)
+	}
+	sort.Strings(fragments)
+	return fragments, nil
+}
+
+// setOrigin records source as the origin of node and everything beneath it.
+func (d *document) setOrigin(node *yaml.Node, source Source) {
+	d.origins[node] = source
+	for _, child := range node.Content {
+		d.setOrigin(child, source)
+	}
+}
+
+// latestOrigin returns the origin of node or of the node beneath it read from the file
+// merged last. Merging updates mappings in place, so a mapping node keeps the origin
+// of the first file to set it while its entries may come from later files.
+func (d *document) latestOrigin(node *yaml.Node) Source {
+	latest := d.origins[node]
+	for _, child := range node.Content {
+		if origin := d.latestOrigin(child); d.mergeIndex(origin) > d.mergeIndex(latest) {
+			latest = origin
+		}
+	}
+	return latest
+}
+
+// mergeIndex returns the position of the source's file in merge order, or -1.
+func (d *document) mergeIndex(source Source) int {
+	for i := len(d.merged) - 1; i >= 0; i-- {
+		if d.merged[i] == source.Name {
+			return i
+		}
+	}
+	return -1
+}
+
+// merge returns the result of merging src over dst, following the override markers.
+func (d *document) merge(dst, src *yaml.Node, markers map[*yaml.Node]string) *yaml.Node {
+	switch {
+	case dst == nil:
+		return src
+	case src.Kind == yaml.ScalarNode && src.Tag == "!!null" && dst.Kind == yaml.MappingNode:
+		return dst // An empty section changes nothing.
+	case dst.Kind == yaml.MappingNode && src.Kind == yaml.MappingNode && markers[src] != markerReplace:
+		for i := 0; i+1 < len(src.Content); i += 2 {
+			key, value := src.Content[i], src.Content[i+1]
+			if j := mappingIndex(dst, key.Value); j >= 0 {
+				dst.Content[j+1] = d.merge(dst.Content[j+1], value, markers)
+			} else {
+				dst.Content = append(dst.Content, key, value)
+			}
+		}
+		return dst
+	case dst.Kind == yaml.SequenceNode && src.Kind == yaml.SequenceNode && markers[src] == markerAppend:
+		combined := &yaml.Node{Kind: yaml.SequenceNode, Tag: "!!seq", Style: dst.Style}
+		combined.Content = append(slices.Clone(dst.Content), src.Content...)
+		d.origins[combined] = d.origins[src]
+		return combined
+	default:
+		return src
+	}
+}
+
+// mappingIndex returns the index of key in a mapping node's content, or -1.
+func mappingIndex(mapping *yaml.Node, key string) int {
+	for i := 0; i+1 < len(mapping.Content); i += 2 {
+		if mapping.Content[i].Value == key {
+			return i
+		}
+	}
+	return -1
+}
+
+// takeIncludes removes the include key from a file's root mapping and returns the
+// files it names, resolved against the file's directory with globs expanded.
+func takeIncludes(root *yaml.Node, path string) ([]string, error) {
+	if root.Kind != yaml.MappingNode {
+		return nil, nil
+	}
+	i := mappingIndex(root, includeKey)
+	if i < 0 {
+		return nil, nil
+	}
+	node := root.Content[i+1]
+	root.Content = slices.Delete(root.Content, i, i+2)
+
+	var patterns []string
+	if err := node.Decode(&patterns); err != nil {
+		return nil, DecodeErrors{{File: path, Line: node.Line, Column: node.Column, Path: includeKey,
+			Message: "include must be a list of file paths"}}
+	}
+	var files []string
+	for _, pattern := range patterns {
+		if !filepath.IsAbs(pattern) {
+			pattern = filepath.Join(filepath.Dir(path), pattern)
+		}
+		matches, err := filepath.Glob(pattern)
+		if err != nil {
+			return nil, errors.Wrapf(err, "takeIncludes: invalid include pattern in %s", path)
+		}
+		if matches == nil && !strings.ContainsAny(pattern, `*?[\`) {
+			matches = []string{pattern} // Reported as missing by mergeFile.
+		}
+		files = append(files, matches...)
+	}
+	return files, nil
+}
+
+// takeMarkers strips the override markers from the nodes of a file, so the values
+// decode normally, and returns them by node. A marker on the wrong kind of value
+// is reported as a DecodeError.
+func takeMarkers(root *yaml.Node, path string) (map[*yaml.Node]string, error) {
+	markers := make(map[*yaml.Node]string)
+	var errs DecodeErrors
+	var walk func(node *yaml.Node)
+	walk = func(node *yaml.Node) {
+		switch node.Tag {
+		case markerReplace, markerAppend:
+			want, kind := yaml.MappingNode, "mapping"
+			if node.Tag == markerAppend {
+				want, kind = yaml.SequenceNode, "sequence"
+			}
+			if node.Kind != want {
+				errs = append(errs, &DecodeError{File: path, Line: node.Line, Column: node.Column,
+					Message: node.Tag + " must mark a " + kind})
+			}
+			markers[node] = node.Tag
+			node.Tag = ""
+		}
+		for _, child := range node.Content {
+			walk(child)
+		}
+	}
+	walk(root)
+	if len(errs) > 0 {
+		return nil, errs
+	}
+	return markers, nil
+}
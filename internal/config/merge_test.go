@@ -0,0 +1,100 @@
+// file: internal/config/merge_test.go
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/dkoosis/hello-tool-base/internal/logging"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+)
+
+// TestLoader_MergesIncludesAndConfD_When_BaseFileHasFragments (ADR-008 Naming)
+func TestLoader_MergesIncludesAndConfD_When_BaseFileHasFragments(t *testing.T) {
+	// Arrange
+	dir := t.TempDir()
+	require.NoError(t, os.MkdirAll(filepath.Join(dir, "conf.d"), 0o755))
+	shared := writeFile(t, dir, "shared.yaml", "server:\n  name: Shared\n  port: 7000\nmcp:\n  enabled: true\n  path: /shared\n")
+	base := writeFile(t, dir, "config.yaml", "include: [shared.yaml]\nserver:\n  port: 8000\n")
+	port := writeFile(t, dir, "conf.d/10-port.yaml", "server:\n  port: 9000\n")
+	mcp := writeFile(t, dir, "conf.d/20-mcp.yaml", "mcp: !replace\n  path: /variant\n")
+
+	// Act
+	cfg, sources, err := (&Loader{Path: base}).Load()
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, "Shared", cfg.Server.Name, "included values apply")
+	assert.Equal(t, 9000, cfg.Server.Port, "conf.d overrides the base file, which overrides its includes")
+	assert.Equal(t, "/variant", cfg.MCP.Path)
+	assert.False(t, cfg.MCP.Enabled, "!replace drops the earlier mapping's keys")
+	assert.Equal(t, Source{Layer: LayerFile, Name: shared}, sources["server.name"])
+	assert.Equal(t, Source{Layer: LayerFile, Name: port}, sources["server.port"])
+	assert.Equal(t, Source{Layer: LayerFile, Name: mcp}, sources["mcp.path"])
+	assert.Equal(t, Source{Layer: LayerDefault}, sources["mcp.enabled"])
+}
+
+// TestDocumentMerge_ReplacesOrAppendsSequences_When_MarkerPresent (ADR-008 Naming)
+func TestDocumentMerge_ReplacesOrAppendsSequences_When_MarkerPresent(t *testing.T) {
+	// Arrange
+	dir := t.TempDir()
+	first := writeFile(t, dir, "a.yaml", "replaced: [1, 2]\nappended: [1, 2]\nnested:\n  keep: true\n")
+	second := writeFile(t, dir, "b.yaml", "replaced: [3]\nappended: !append [3]\nnested:\n  added: true\n")
+	doc := newDocument(logging.GetNoopLogger())
+	parse := func(path string) *yaml.Node {
+		data, err := os.ReadFile(path)
+		require.NoError(t, err)
+		var n yaml.Node
+		require.NoError(t, yaml.Unmarshal(data, &n))
+		return n.Content[0]
+	}
+
+	// Act
+	a, b := parse(first), parse(second)
+	markers, err := takeMarkers(b, second)
+	require.NoError(t, err)
+	merged := doc.merge(doc.merge(nil, a, nil), b, markers)
+	var got map[string]any
+	require.NoError(t, merged.Decode(&got))
+
+	// Assert
+	assert.Equal(t, []any{3}, got["replaced"])
+	assert.Equal(t, []any{1, 2, 3}, got["appended"])
+	assert.Equal(t, map[string]any{"keep": true, "added": true}, got["nested"])
+}
+
+// TestLoader_Fails_When_IncludesFormCycle (ADR-008 Naming)
+func TestLoader_Fails_When_IncludesFormCycle(t *testing.T) {
+	// Arrange
+	dir := t.TempDir()
+	writeFile(t, dir, "a.yaml", "include: [b.yaml]\n")
+	writeFile(t, dir, "b.yaml", "include: [a.yaml]\n")
+
+	// Act
+	_, _, err := (&Loader{Path: filepath.Join(dir, "a.yaml")}).Load()
+
+	// Assert
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "include cycle")
+}
+
+// TestLoaderDump_CommentsEachValueWithItsFile_When_FilesMerged (ADR-008 Naming)
+func TestLoaderDump_CommentsEachValueWithItsFile_When_FilesMerged(t *testing.T) {
+	// Arrange
+	dir := t.TempDir()
+	base := writeFile(t, dir, "config.yaml", "server:\n  port: 8000\n  name: Base\n")
+	overlay := writeFile(t, dir, "config.staging.yaml", "server:\n  port: 8001\n")
+	var out strings.Builder
+
+	// Act
+	err := (&Loader{Path: base, Environment: "staging"}).Dump(&out)
+
+	// Assert
+	require.NoError(t, err)
+	assert.Contains(t, out.String(), "port: 8001 # overlay "+overlay)
+	assert.Contains(t, out.String(), "name: Base # file "+base)
+}
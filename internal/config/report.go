@@ -0,0 +1,118 @@
+// file: internal/config/report.go
+package config
+
+// report.go describes the resolved configuration with the source of each value, redacting secrets.
+
+import (
+	"fmt"
+	"io"
+	"reflect"
+	"text/tabwriter"
+	"time"
+
+	"github.com/cockroachdb/errors"
+	"github.com/dkoosis/hello-tool-base/internal/apperrors"
+)
+
+// Redacted replaces the value of secret fields in reports.
+const Redacted = "[REDACTED]"
+
+// Setting is one resolved field in a configuration report.
+type Setting struct {
+	Path   string `json:"path"`
+	Value  any    `json:"value"`
+	Source Source `json:"source"`
+}
+
+// Report lists every field of cfg, in declaration order, with its source.
+// Fields tagged `secret:"true"` are shown as Redacted when set.
+func Report(cfg *Config, sources Sources) []Setting {
+	var settings []Setting
+	walkFields(cfg, func(f field) {
+		value := displayValue(f)
+		source, ok := sources[f.Path]
+		if !ok {
+			source = Source{Layer: LayerDefault}
+		}
+		settings = append(settings, Setting{Path: f.Path, Value: value, Source: source})
+	})
+	return settings
+}
+
+// displayValue returns the field's value for reports: secrets that are set become
+// Redacted and durations are rendered as strings such as "15s".
+func displayValue(f field) any {
+	switch {
+	case f.secret() && !f.Value.IsZero():
+		return Redacted
+	case f.Value.Type() == durationType:
+		return time.Duration(f.Value.Int()).String()
+	}
+	return f.Value.Interface()
+}
+
+// WriteReport prints settings as aligned "path  value  source" lines.
+func WriteReport(w io.Writer, settings []Setting) error {
+	tw := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+	for _, s := range settings {
+		if _, err := fmt.Fprintf(tw, "%s\t%v\t# %s\n", s.Path, s.Value, s.Source); err != nil {
+			return err
+		}
+	}
+	return tw.Flush()
+}
+
+// Change is a field whose value differs between two configurations.
+// Secret values are shown as Redacted.
+type Change struct {
+	Path string `json:"path"`
+	Old  any    `json:"old"`
+	New  any    `json:"new"`
+}
+
+// String renders the change as "path: old -> new".
+func (c Change) String() string {
+	return fmt.Sprintf("%s: %v -> %v", c.Path, c.Old, c.New)
+}
+
+// Diff lists the fields whose values differ between prev and next, in declaration order.
+func Diff(prev, next *Config) []Change {
+	nextFields := make(map[string]field)
+	walkFields(next, func(f field) { nextFields[f.Path] = f })
+
+	var changes []Change
+	walkFields(prev, func(f field) {
+		n := nextFields[f.Path]
+		if reflect.DeepEqual(f.Value.Interface(), n.Value.Interface()) {
+			return
+		}
+		changes = append(changes, Change{Path: f.Path, Old: displayValue(f), New: displayValue(n)})
+	})
+	return changes
+}
+
+// WriteProblems prints a load or validation error for humans, one problem per line:
+// file positions for DecodeErrors, field paths for validation violations, and the
+// error message otherwise.
+func WriteProblems(w io.Writer, err error) error {
+	var lines []string
+	var decodeErrs DecodeErrors
+	switch violations := apperrors.ViolationsFrom(err); {
+	case errors.As(err, &decodeErrs):
+		for _, e := range decodeErrs {
+			lines = append(lines, e.Error())
+		}
+	case len(violations) > 0:
+		for _, v := range violations {
+			lines = append(lines, v.Field+": "+v.Message)
+		}
+	default:
+		lines = append(lines, err.Error())
+	}
+	for _, line := range lines {
+		if _, err := fmt.Fprintf(w, "  %s\n", line); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,121 @@
+// file: internal/config/schema.go
+package config
+
+// schema.go derives a JSON Schema for config files from the Config structure and its tags.
+
+import (
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// SchemaID is the JSON Schema dialect of Schema.
+const SchemaID = "https://json-schema.org/draft/2020-12/schema"
+
+// durationPattern matches the durations accepted by time.ParseDuration, e.g. "1m30s".
+const durationPattern = `^[-+]?(([0-9]+(\.[0-9]*)?|\.[0-9]+)(ns|us|µs|ms|s|m|h))+$|^0$`
+
+// JSONSchema is the subset of JSON Schema used to describe configuration files.
+type JSONSchema struct {
+	Schema               string                 `json:"$schema,omitempty"`
+	Title                string                 `json:"title,omitempty"`
+	Description          string                 `json:"description,omitempty"`
+	Type                 string                 `json:"type,omitempty"`
+	Enum                 []string               `json:"enum,omitempty"`
+	Pattern              string                 `json:"pattern,omitempty"`
+	Minimum              *float64               `json:"minimum,omitempty"`
+	Maximum              *float64               `json:"maximum,omitempty"`
+	Default              any                    `json:"default,omitempty"`
+	Items                *JSONSchema            `json:"items,omitempty"`
+	Properties           map[string]*JSONSchema `json:"properties,omitempty"`
+	AdditionalProperties any                    `json:"additionalProperties,omitempty"`
+}
+
+// Schema describes the YAML configuration file: every key with its type, its default
+// from DefaultConfig and the field's `description`, `enum`, `minimum` and `maximum`
+// tags, plus the top-level include list. Sections reject unknown keys, as the Loader does.
+func Schema() *JSONSchema {
+	s := sectionSchema(reflect.ValueOf(DefaultConfig()).Elem())
+	s.Properties[includeKey] = &JSONSchema{
+		Type:        "array",
+		Items:       &JSONSchema{Type: "string"},
+		Description: "Files merged beneath this one, relative to it; glob patterns are expanded.",
+	}
+	s.Schema = SchemaID
+	s.Title = "hello-tool-base configuration"
+	return s
+}
+
+// sectionSchema describes the struct v, using its field values as defaults.
+func sectionSchema(v reflect.Value) *JSONSchema {
+	t := v.Type()
+	s := &JSONSchema{Type: "object", Properties: make(map[string]*JSONSchema), AdditionalProperties: false}
+	for i := 0; i < t.NumField(); i++ {
+		sf := t.Field(i)
+		name := yamlName(sf)
+		if name == "" {
+			continue
+		}
+		var prop *JSONSchema
+		if isSection(sf.Type) {
+			prop = sectionSchema(v.Field(i))
+		} else {
+			prop = leafSchema(sf.Type)
+			f := field{Value: v.Field(i), Struct: sf}
+			if !f.secret() && !v.Field(i).IsZero() {
+				prop.Default = displayValue(f)
+			}
+			if enum := sf.Tag.Get("enum"); enum != "" {
+				prop.Enum = strings.Split(enum, ",")
+			}
+			prop.Minimum = tagFloat(sf, "minimum")
+			prop.Maximum = tagFloat(sf, "maximum")
+		}
+		prop.Description = sf.Tag.Get("description")
+		s.Properties[name] = prop
+	}
+	return s
+}
+
+// leafSchema maps the type of a leaf field to its schema, following setFromString.
+func leafSchema(t reflect.Type) *JSONSchema {
+	if t == durationType {
+		return &JSONSchema{Type: "string", Pattern: durationPattern}
+	}
+	switch t.Kind() {
+	case reflect.Pointer:
+		return leafSchema(t.Elem()) // Optional values, e.g. a flag's percentage.
+	case reflect.String:
+		return &JSONSchema{Type: "string"}
+	case reflect.Struct:
+		if isSection(t) {
+			return sectionSchema(reflect.New(t).Elem()) // e.g. the values of a map of rules.
+		}
+		return &JSONSchema{Type: "string"} // Text types such as Secret.
+	case reflect.Bool:
+		return &JSONSchema{Type: "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return &JSONSchema{Type: "integer"}
+	case reflect.Float32, reflect.Float64:
+		return &JSONSchema{Type: "number"}
+	case reflect.Slice:
+		return &JSONSchema{Type: "array", Items: leafSchema(t.Elem())}
+	case reflect.Map:
+		return &JSONSchema{Type: "object", AdditionalProperties: leafSchema(t.Elem())}
+	}
+	return &JSONSchema{}
+}
+
+// tagFloat parses a numeric struct tag, returning nil if it is absent or malformed.
+func tagFloat(sf reflect.StructField, key string) *float64 {
+	raw, ok := sf.Tag.Lookup(key)
+	if !ok {
+		return nil
+	}
+	n, err := strconv.ParseFloat(raw, 64)
+	if err != nil {
+		return nil
+	}
+	return &n
+}
@@ -0,0 +1,65 @@
+// file: internal/config/schema_test.go
+package config
+
+import (
+	"encoding/json"
+	"flag"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// schemaPath is the committed JSON Schema, relative to this package.
+const schemaPath = "../../config.schema.json"
+
+// updateSchema regenerates the committed config.schema.json instead of comparing against it.
+var updateSchema = flag.Bool("update", false, "rewrite config.schema.json from the Config structure")
+
+// TestSchema_MatchesCommittedFile_When_GeneratedFromConfig (ADR-008 Naming)
+func TestSchema_MatchesCommittedFile_When_GeneratedFromConfig(t *testing.T) {
+	// Arrange
+	schema := Schema()
+
+	// Act
+	generated, err := json.MarshalIndent(schema, "", "  ")
+	require.NoError(t, err)
+	generated = append(generated, '\n')
+
+	// Assert
+	if *updateSchema {
+		require.NoError(t, os.WriteFile(schemaPath, generated, 0o644))
+		return
+	}
+	committed, err := os.ReadFile(schemaPath)
+	require.NoError(t, err)
+	assert.Equal(t, string(generated), string(committed),
+		"config.schema.json is out of date; run: go test ./internal/config -run TestSchema -update")
+}
+
+// TestSchema_DescribesEveryField_When_Generated (ADR-008 Naming)
+func TestSchema_DescribesEveryField_When_Generated(t *testing.T) {
+	// Arrange
+	schema := Schema()
+
+	// Act
+	port := schema.Properties["server"].Properties["port"]
+	mode := schema.Properties["server"].Properties["responseValidation"]
+	timeout := schema.Properties["server"].Properties["readTimeout"]
+
+	// Assert
+	assert.Equal(t, "integer", port.Type)
+	assert.EqualValues(t, 8080, port.Default)
+	require.NotNil(t, port.Maximum)
+	assert.EqualValues(t, 65535, *port.Maximum)
+	assert.Equal(t, []string{"off", "log", "strict"}, mode.Enum)
+	assert.Equal(t, "15s", timeout.Default)
+	assert.Regexp(t, timeout.Pattern, "1m30s")
+	assert.Nil(t, schema.Properties["admin"].Properties["token"].Default, "secrets have no default")
+	for _, path := range fieldPaths() {
+		f, ok := lookupField(DefaultConfig(), path)
+		require.True(t, ok)
+		assert.NotEmpty(t, f.Struct.Tag.Get("description"), "%s has no description tag", path)
+	}
+}
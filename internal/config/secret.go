@@ -0,0 +1,206 @@
+// file: internal/config/secret.go
+package config
+
+// secret.go defines the Secret value type and resolves secret references through providers.
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+
+	"github.com/cockroachdb/errors"
+)
+
+// Secret reference prefixes. "secret://<provider>/<key>" names any registered provider;
+// "env://<NAME>" is shorthand for "secret://env/<NAME>".
+const (
+	secretScheme = "secret://"
+	envScheme    = "env://"
+)
+
+// ErrSecretNotFound is returned by providers when the referenced secret does not exist.
+var ErrSecretNotFound = errors.New("secret not found")
+
+// Secret is a configuration value that must not be displayed. It prints, logs and
+// marshals as Redacted; only Value returns the plain text.
+//
+// In YAML, environment variables and flags it is either the secret itself or a
+// reference such as "secret://file/run/secrets/stripe_key" or "env://STRIPE_KEY",
+// which the Loader resolves through a SecretProvider.
+type Secret struct {
+	value string
+	// ref is the unresolved reference, or "" for a literal secret.
+	ref string
+}
+
+var secretType = reflect.TypeOf(Secret{})
+
+// NewSecret returns a Secret holding value literally.
+func NewSecret(value string) Secret {
+	return Secret{value: value}
+}
+
+// Value returns the plain text of the secret.
+func (s Secret) Value() string {
+	return s.value
+}
+
+// IsSet reports whether the secret has a value or a reference.
+func (s Secret) IsSet() bool {
+	return s.value != "" || s.ref != ""
+}
+
+// Reference returns the reference the secret was resolved from, or "" for a literal.
+func (s Secret) Reference() string {
+	return s.ref
+}
+
+// String returns Redacted for a set secret and "" otherwise, so %v and %s never leak it.
+func (s Secret) String() string {
+	if !s.IsSet() {
+		return ""
+	}
+	return Redacted
+}
+
+// GoString keeps %#v from printing the fields.
+func (s Secret) GoString() string {
+	return "config.Secret(" + s.String() + ")"
+}
+
+// LogValue redacts the secret in slog output.
+func (s Secret) LogValue() slog.Value {
+	return slog.StringValue(s.String())
+}
+
+// MarshalJSON redacts the secret in JSON.
+func (s Secret) MarshalJSON() ([]byte, error) {
+	return json.Marshal(s.String())
+}
+
+// MarshalYAML redacts the secret in YAML.
+func (s Secret) MarshalYAML() (any, error) {
+	return s.String(), nil
+}
+
+// UnmarshalText accepts a literal secret or a reference to be resolved by the Loader.
+func (s *Secret) UnmarshalText(text []byte) error {
+	raw := string(text)
+	if strings.HasPrefix(raw, secretScheme) || strings.HasPrefix(raw, envScheme) {
+		if _, _, err := parseSecretRef(raw); err != nil {
+			return err
+		}
+		*s = Secret{ref: raw}
+		return nil
+	}
+	*s = Secret{value: raw}
+	return nil
+}
+
+// parseSecretRef splits a reference into its provider name and key.
+func parseSecretRef(ref string) (provider, key string, err error) {
+	if name, ok := strings.CutPrefix(ref, envScheme); ok {
+		provider, key = "env", name
+	} else if rest, ok := strings.CutPrefix(ref, secretScheme); ok {
+		provider, key, _ = strings.Cut(rest, "/")
+	}
+	if provider == "" || key == "" {
+		return "", "", errors.Newf("parseSecretRef: %q is not of the form secret://<provider>/<key> or env://<NAME>", ref)
+	}
+	return provider, key, nil
+}
+
+// SecretProvider looks up secrets by key for references of the form
+// "secret://<name>/<key>", where name is the provider's registered name.
+type SecretProvider interface {
+	Resolve(ctx context.Context, key string) (string, error)
+}
+
+// DefaultSecretProviders returns the providers every Loader has unless overridden:
+// "file" reads files by absolute path (including Secret Manager volumes mounted into
+// Cloud Run) and "env" reads environment variables.
+func DefaultSecretProviders() map[string]SecretProvider {
+	return map[string]SecretProvider{
+		"file": FileProvider{Root: "/"},
+		"env":  EnvProvider{},
+	}
+}
+
+// FileProvider reads each secret from a file named by its key, relative to Root.
+// A trailing newline is removed.
+type FileProvider struct {
+	Root string
+}
+
+// Resolve reads the secret file Root/key.
+func (p FileProvider) Resolve(_ context.Context, key string) (string, error) {
+	path := filepath.Join(p.Root, filepath.FromSlash(key))
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return "", errors.Wrapf(ErrSecretNotFound, "FileProvider.Resolve: no file %s", path)
+	}
+	if err != nil {
+		return "", errors.Wrapf(err, "FileProvider.Resolve: failed to read %s", path)
+	}
+	return strings.TrimRight(string(data), "\r\n"), nil
+}
+
+// EnvProvider reads each secret from the environment variable named by its key.
+type EnvProvider struct{}
+
+// Resolve returns the value of the environment variable key, which must be non-empty.
+func (EnvProvider) Resolve(_ context.Context, key string) (string, error) {
+	value := os.Getenv(key)
+	if value == "" {
+		return "", errors.Wrapf(ErrSecretNotFound, "EnvProvider.Resolve: %s is not set", key)
+	}
+	return value, nil
+}
+
+// MemoryProvider serves secrets from a map, for tests.
+type MemoryProvider map[string]string
+
+// Resolve returns the secret stored under key.
+func (p MemoryProvider) Resolve(_ context.Context, key string) (string, error) {
+	value, ok := p[key]
+	if !ok {
+		return "", errors.Wrapf(ErrSecretNotFound, "MemoryProvider.Resolve: no secret %q", key)
+	}
+	return value, nil
+}
+
+// resolveSecrets replaces every Secret reference in cfg with the value from its provider.
+// Errors name the field and reference, never a secret value.
+func resolveSecrets(ctx context.Context, cfg *Config, providers map[string]SecretProvider) error {
+	var firstErr error
+	walkFields(cfg, func(f field) {
+		if firstErr != nil || f.Value.Type() != secretType {
+			return
+		}
+		secret := f.Value.Addr().Interface().(*Secret)
+		if secret.ref == "" {
+			return
+		}
+		name, key, err := parseSecretRef(secret.ref)
+		if err != nil {
+			firstErr = errors.Wrapf(err, "resolveSecrets: invalid reference for %s", f.Path)
+			return
+		}
+		provider, ok := providers[name]
+		if !ok {
+			firstErr = errors.Newf("resolveSecrets: unknown secret provider %q for %s", name, f.Path)
+			return
+		}
+		value, err := provider.Resolve(ctx, key)
+		if err != nil {
+			firstErr = errors.Wrapf(err, "resolveSecrets: failed to resolve %s from %s", f.Path, secret.ref)
+			return
+		}
+		secret.value = value
+	})
+	return firstErr
+}
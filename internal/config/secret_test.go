@@ -0,0 +1,85 @@
+// file: internal/config/secret_test.go
+package config
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"testing"
+
+	"github.com/cockroachdb/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestSecret_RedactsValue_When_FormattedLoggedOrMarshalled (ADR-008 Naming)
+func TestSecret_RedactsValue_When_FormattedLoggedOrMarshalled(t *testing.T) {
+	// Arrange
+	secret := NewSecret("sk_live_123")
+	var logged bytes.Buffer
+	logger := slog.New(slog.NewJSONHandler(&logged, nil))
+
+	// Act
+	jsonOut, err := json.Marshal(struct{ Key Secret }{secret})
+	logger.Info("loaded", "key", secret)
+	formatted := fmt.Sprintf("%v %s %+v %#v", secret, secret, secret, secret)
+
+	// Assert
+	require.NoError(t, err)
+	for _, out := range []string{string(jsonOut), logged.String(), formatted} {
+		assert.NotContains(t, out, "sk_live_123")
+		assert.Contains(t, out, Redacted)
+	}
+	assert.Equal(t, "sk_live_123", secret.Value())
+}
+
+// TestLoader_ResolvesSecretReferences_When_ProvidersAreRegistered (ADR-008 Naming)
+func TestLoader_ResolvesSecretReferences_When_ProvidersAreRegistered(t *testing.T) {
+	// Arrange
+	dir := t.TempDir()
+	writeFile(t, dir, "admin_token", "from-file\n")
+	base := writeFile(t, dir, "config.yaml", "admin:\n  token: secret://vault/admin\n")
+	fileRef := "secret://file/" + dir + "/admin_token"
+
+	tests := []struct {
+		name string
+		env  map[string]string
+		want string
+	}{
+		{name: "custom provider", want: "from-vault"},
+		{name: "file provider", env: map[string]string{"ADMIN_TOKEN": fileRef}, want: "from-file"},
+		{name: "env provider", env: map[string]string{"ADMIN_TOKEN": "env://UPSTREAM_TOKEN", "UPSTREAM_TOKEN": "from-env"}, want: "from-env"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for k, v := range tt.env {
+				t.Setenv(k, v)
+			}
+			loader := &Loader{Path: base, SecretProviders: map[string]SecretProvider{"vault": MemoryProvider{"admin": "from-vault"}}}
+
+			// Act
+			cfg, _, err := loader.Load()
+
+			// Assert
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, cfg.Admin.Token.Value())
+		})
+	}
+}
+
+// TestLoader_FailsWithoutLeakingValue_When_SecretCannotBeResolved (ADR-008 Naming)
+func TestLoader_FailsWithoutLeakingValue_When_SecretCannotBeResolved(t *testing.T) {
+	// Arrange
+	t.Setenv("ADMIN_TOKEN", "secret://vault/missing")
+	loader := &Loader{SecretProviders: map[string]SecretProvider{"vault": MemoryProvider{"other": "hunter2"}}}
+
+	// Act
+	_, _, err := loader.Load()
+
+	// Assert
+	require.Error(t, err)
+	assert.True(t, errors.Is(err, ErrSecretNotFound))
+	assert.Contains(t, err.Error(), "admin.token")
+	assert.NotContains(t, err.Error(), "hunter2")
+}
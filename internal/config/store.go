@@ -0,0 +1,178 @@
+// file: internal/config/store.go
+package config
+
+// store.go holds the active configuration and reloads it on SIGHUP or when its files change.
+
+import (
+	"context"
+	"crypto/sha256"
+	"fmt"
+	"os"
+	"os/signal"
+	"sync"
+	"sync/atomic"
+	"syscall"
+	"time"
+
+	"github.com/cockroachdb/errors"
+	"github.com/dkoosis/hello-tool-base/internal/apperrors"
+	"github.com/dkoosis/hello-tool-base/internal/logging"
+)
+
+// DefaultWatchInterval is how often Watch checks the configuration files for changes.
+const DefaultWatchInterval = 5 * time.Second
+
+// Subscriber is notified after a reload replaces the active configuration.
+// It runs on the reloading goroutine and must not modify either configuration.
+type Subscriber func(prev, next *Config)
+
+// snapshot pairs a configuration with the sources of its values, so both swap together.
+type snapshot struct {
+	cfg     *Config
+	sources Sources
+}
+
+// Store holds the active configuration. Readers call Config for the current value on
+// every use rather than keeping the pointer, so a reload takes effect without a restart.
+// The returned configuration is shared and must be treated as read-only.
+type Store struct {
+	loader  *Loader
+	current atomic.Pointer[snapshot]
+
+	// mu serializes reloads and guards subscribers.
+	mu          sync.Mutex
+	subscribers []Subscriber
+}
+
+// NewStore loads and validates the configuration with loader and returns a Store
+// that can reload it. The error is the one Loader.Load returns.
+func NewStore(loader *Loader) (*Store, error) {
+	cfg, sources, err := loader.Load()
+	if err != nil {
+		return nil, err
+	}
+	s := &Store{loader: loader}
+	s.current.Store(&snapshot{cfg: cfg, sources: sources})
+	return s, nil
+}
+
+// NewStaticStore returns a Store holding cfg that cannot be reloaded, for tests and
+// for configurations that were not read from files.
+func NewStaticStore(cfg *Config, sources Sources) *Store {
+	s := &Store{}
+	s.current.Store(&snapshot{cfg: cfg, sources: sources})
+	return s
+}
+
+// log returns the reload logger. It is looked up on each use so that it follows the
+// logging configuration main applies after the store is created.
+func (s *Store) log() logging.Logger {
+	return logging.GetLogger("config_reload")
+}
+
+// Config returns the active configuration.
+func (s *Store) Config() *Config {
+	return s.current.Load().cfg
+}
+
+// Sources returns the sources of the active configuration's values.
+func (s *Store) Sources() Sources {
+	return s.current.Load().sources
+}
+
+// Subscribe registers fn to be called, in registration order, after each reload
+// that changes the configuration.
+func (s *Store) Subscribe(fn Subscriber) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.subscribers = append(s.subscribers, fn)
+}
+
+// Reload resolves the configuration again and, if it is valid and differs from the
+// active one, swaps it in and notifies subscribers. On failure the active
+// configuration is kept; a configuration that fails validation is logged along
+// with the changes it would have made.
+func (s *Store) Reload() error {
+	if s.loader == nil {
+		return errors.New("Store.Reload: static configuration cannot be reloaded")
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	prev := s.current.Load()
+	cfg, sources, err := s.loader.resolve()
+	if err != nil {
+		s.log().Error("Configuration reload failed; keeping the previous configuration.", "error", fmt.Sprintf("%+v", err))
+		return errors.Wrap(err, "Store.Reload: failed to load configuration")
+	}
+	changes := Diff(prev.cfg, cfg)
+	if err := cfg.Validate(); err != nil {
+		s.log().Error("Configuration reload rejected; keeping the previous configuration.",
+			"rejectedChanges", changes, "violations", apperrors.ViolationsFrom(err), "error", fmt.Sprintf("%+v", err))
+		return errors.Wrap(err, "Store.Reload: invalid configuration")
+	}
+	if len(changes) == 0 {
+		s.log().Debug("Configuration reloaded without changes.")
+		return nil
+	}
+
+	s.current.Store(&snapshot{cfg: cfg, sources: sources})
+	s.log().Info("Configuration reloaded.", "changes", changes)
+	for _, fn := range s.subscribers {
+		fn(prev.cfg, cfg)
+	}
+	return nil
+}
+
+// Watch reloads the configuration on SIGHUP and whenever one of its files changes,
+// checking the files every interval, until ctx is done. A non-positive interval
+// disables polling, leaving only SIGHUP. Failed reloads are logged by Reload and do
+// not stop watching.
+func (s *Store) Watch(ctx context.Context, interval time.Duration) {
+	if s.loader == nil {
+		return
+	}
+	hup := make(chan os.Signal, 1)
+	signal.Notify(hup, syscall.SIGHUP)
+	defer signal.Stop(hup)
+	var tick <-chan time.Time
+	if interval > 0 {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		tick = ticker.C
+	}
+
+	last := s.fingerprint()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-hup:
+			s.log().Info("SIGHUP received, reloading configuration.")
+			_ = s.Reload()
+		case <-tick:
+			current := s.fingerprint()
+			if current == last {
+				continue
+			}
+			last = current
+			s.log().Info("Configuration file changed, reloading configuration.")
+			_ = s.Reload()
+		}
+	}
+}
+
+// fingerprint hashes the contents of the loader's files, including includes and conf.d
+// fragments, so edits are detected even when they keep the size and modification time.
+// A missing file hashes as empty.
+func (s *Store) fingerprint() [sha256.Size]byte {
+	h := sha256.New()
+	for _, path := range s.loader.watchedFiles() {
+		data, _ := os.ReadFile(path)
+		fmt.Fprintf(h, "%s\x00%d\x00", path, len(data))
+		h.Write(data)
+	}
+	var sum [sha256.Size]byte
+	copy(sum[:], h.Sum(nil))
+	return sum
+}
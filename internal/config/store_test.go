@@ -0,0 +1,113 @@
+// file: internal/config/store_test.go
+package config
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/dkoosis/hello-tool-base/internal/apperrors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestStore_SwapsConfigAndNotifiesSubscribers_When_ReloadedFileIsValid (ADR-008 Naming)
+func TestStore_SwapsConfigAndNotifiesSubscribers_When_ReloadedFileIsValid(t *testing.T) {
+	// Arrange
+	path := writeFile(t, t.TempDir(), "config.yaml", "server:\n  port: 9000\n")
+	store, err := NewStore(&Loader{Path: path})
+	require.NoError(t, err)
+	var gotPrev, gotNext *Config
+	store.Subscribe(func(prev, next *Config) { gotPrev, gotNext = prev, next })
+	require.NoError(t, os.WriteFile(path, []byte("server:\n  port: 9001\n"), 0o600))
+
+	// Act
+	err = store.Reload()
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, 9001, store.Config().Server.Port)
+	require.NotNil(t, gotPrev)
+	assert.Equal(t, 9000, gotPrev.Server.Port)
+	assert.Same(t, store.Config(), gotNext)
+	assert.Equal(t, Source{Layer: LayerFile, Name: path}, store.Sources()["server.port"])
+}
+
+// TestStore_KeepsPreviousConfig_When_ReloadedFileIsInvalid (ADR-008 Naming)
+func TestStore_KeepsPreviousConfig_When_ReloadedFileIsInvalid(t *testing.T) {
+	// Arrange
+	path := writeFile(t, t.TempDir(), "config.yaml", "server:\n  port: 9000\n")
+	store, err := NewStore(&Loader{Path: path})
+	require.NoError(t, err)
+	before := store.Config()
+	notified := false
+	store.Subscribe(func(prev, next *Config) { notified = true })
+	require.NoError(t, os.WriteFile(path, []byte("server:\n  port: -1\n"), 0o600))
+
+	// Act
+	err = store.Reload()
+
+	// Assert
+	require.Error(t, err)
+	assert.Len(t, apperrors.ViolationsFrom(err), 1)
+	assert.Same(t, before, store.Config())
+	assert.False(t, notified)
+}
+
+// TestStore_ReloadsConfig_When_WatchedFileChanges (ADR-008 Naming)
+func TestStore_ReloadsConfig_When_WatchedFileChanges(t *testing.T) {
+	// Arrange
+	path := writeFile(t, t.TempDir(), "config.yaml", "server:\n  name: before\n")
+	store, err := NewStore(&Loader{Path: path})
+	require.NoError(t, err)
+	reloaded := make(chan string, 1)
+	store.Subscribe(func(prev, next *Config) {
+		select {
+		case reloaded <- next.Server.Name:
+		default:
+		}
+	})
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go store.Watch(ctx, 10*time.Millisecond)
+
+	// Act
+	// Keep editing until a reload is seen, since Watch may take its first
+	// fingerprint after an early edit.
+	var name string
+	deadline := time.After(2 * time.Second)
+	for i := 0; name == ""; i++ {
+		// Replace the file atomically so Watch never reads it half-written.
+		next := writeFile(t, filepath.Dir(path), "next.yaml", fmt.Sprintf("server:\n  name: after-%d\n", i))
+		require.NoError(t, os.Rename(next, path))
+		select {
+		case name = <-reloaded:
+		case <-time.After(50 * time.Millisecond):
+		case <-deadline:
+			t.Fatal("configuration was not reloaded after the file changed")
+		}
+	}
+
+	// Assert
+	assert.Contains(t, name, "after-")
+}
+
+// TestDiff_RedactsSecrets_When_TokenChanges (ADR-008 Naming)
+func TestDiff_RedactsSecrets_When_TokenChanges(t *testing.T) {
+	// Arrange
+	prev, next := DefaultConfig(), DefaultConfig()
+	prev.Admin.Token, next.Admin.Token = NewSecret("old-secret"), NewSecret("new-secret")
+	next.Server.ReadTimeout = time.Second
+
+	// Act
+	changes := Diff(prev, next)
+
+	// Assert
+	assert.Equal(t, []Change{
+		{Path: "server.readTimeout", Old: "15s", New: "1s"},
+		{Path: "admin.token", Old: Redacted, New: Redacted},
+	}, changes)
+}
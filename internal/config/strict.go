@@ -0,0 +1,217 @@
+// file: internal/config/strict.go
+package config
+
+// strict.go checks a parsed YAML document against the Config structure before it is
+// decoded, so typos and wrong types fail loudly with their file position.
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// DecodeError is a problem at one position of a configuration file.
+type DecodeError struct {
+	File   string
+	Line   int
+	Column int
+	// Path is the dotted path of the offending key, e.g. "server.readTimout".
+	Path    string
+	Message string
+	// Suggestion is the closest known key, for unknown keys that look like a typo.
+	Suggestion string
+}
+
+// Error renders the problem as "file:line:column: message (did you mean ...?)".
+func (e *DecodeError) Error() string {
+	msg := fmt.Sprintf("%s:%d:%d: %s", e.File, e.Line, e.Column, e.Message)
+	if e.Suggestion != "" {
+		msg += fmt.Sprintf(" (did you mean %q?)", e.Suggestion)
+	}
+	return msg
+}
+
+// DecodeErrors lists every problem found in a configuration file, in document order.
+type DecodeErrors []*DecodeError
+
+// Error renders one problem per line.
+func (e DecodeErrors) Error() string {
+	lines := make([]string, len(e))
+	for i, err := range e {
+		lines[i] = err.Error()
+	}
+	return strings.Join(lines, "\n")
+}
+
+// checkDocument reports unknown keys, duplicate keys and values that do not decode
+// into the type of their field, for the root node of a YAML document.
+func checkDocument(root *yaml.Node, file string) error {
+	c := &documentChecker{file: file}
+	c.checkSection(root, reflect.TypeOf(Config{}), "")
+	if len(c.errs) == 0 {
+		return nil
+	}
+	return c.errs
+}
+
+type documentChecker struct {
+	file string
+	errs DecodeErrors
+}
+
+func (c *documentChecker) add(node *yaml.Node, path, suggestion, format string, args ...any) {
+	c.errs = append(c.errs, &DecodeError{
+		File: c.file, Line: node.Line, Column: node.Column, Path: path,
+		Message: fmt.Sprintf(format, args...), Suggestion: suggestion,
+	})
+}
+
+// checkSection checks a mapping node against the struct type t.
+func (c *documentChecker) checkSection(node *yaml.Node, t reflect.Type, prefix string) {
+	if node.Kind == yaml.AliasNode {
+		node = node.Alias
+	}
+	if node.Kind == yaml.ScalarNode && node.Tag == "!!null" {
+		return // An empty section keeps its defaults.
+	}
+	if node.Kind != yaml.MappingNode {
+		c.add(node, prefix, "", "expected a mapping for %s, got %s", sectionName(prefix), kindName(node))
+		return
+	}
+
+	fields := make(map[string]reflect.StructField, t.NumField())
+	known := make([]string, 0, t.NumField())
+	for i := 0; i < t.NumField(); i++ {
+		if name := yamlName(t.Field(i)); name != "" {
+			fields[name] = t.Field(i)
+			known = append(known, name)
+		}
+	}
+
+	seen := make(map[string]*yaml.Node, len(node.Content)/2)
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		key, value := node.Content[i], node.Content[i+1]
+		path := key.Value
+		if prefix != "" {
+			path = prefix + "." + key.Value
+		}
+		if first, ok := seen[key.Value]; ok {
+			c.add(key, path, "", "duplicate key %q, first defined at line %d", key.Value, first.Line)
+			continue
+		}
+		seen[key.Value] = key
+
+		sf, ok := fields[key.Value]
+		if !ok {
+			c.add(key, path, suggest(key.Value, known), "unknown key %q in %s", key.Value, sectionName(prefix))
+			continue
+		}
+		if isSection(sf.Type) {
+			c.checkSection(value, sf.Type, path)
+			continue
+		}
+		if sf.Type.Kind() == reflect.Map && isSection(sf.Type.Elem()) {
+			c.checkSectionMap(value, sf.Type.Elem(), path)
+			continue
+		}
+		if err := value.Decode(reflect.New(sf.Type).Interface()); err != nil {
+			c.add(value, path, "", "invalid value for %s: %s", path, decodeMessage(err))
+		}
+	}
+}
+
+// checkSectionMap checks a mapping node whose values are sections of type t, such as
+// the rules of named feature flags.
+func (c *documentChecker) checkSectionMap(node *yaml.Node, t reflect.Type, prefix string) {
+	if node.Kind == yaml.ScalarNode && node.Tag == "!!null" {
+		return
+	}
+	if node.Kind != yaml.MappingNode {
+		c.add(node, prefix, "", "expected a mapping for %s, got %s", sectionName(prefix), kindName(node))
+		return
+	}
+	seen := make(map[string]*yaml.Node, len(node.Content)/2)
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		key, value := node.Content[i], node.Content[i+1]
+		path := prefix + "." + key.Value
+		if first, ok := seen[key.Value]; ok {
+			c.add(key, path, "", "duplicate key %q, first defined at line %d", key.Value, first.Line)
+			continue
+		}
+		seen[key.Value] = key
+		c.checkSection(value, t, path)
+	}
+}
+
+// sectionName describes the section at prefix for error messages.
+func sectionName(prefix string) string {
+	if prefix == "" {
+		return "the top level"
+	}
+	return "section " + prefix
+}
+
+// kindName describes the kind of a YAML node for error messages.
+func kindName(node *yaml.Node) string {
+	switch node.Kind {
+	case yaml.SequenceNode:
+		return "a sequence"
+	case yaml.MappingNode:
+		return "a mapping"
+	default:
+		return fmt.Sprintf("%q", node.Value)
+	}
+}
+
+// decodeMessage strips the "yaml: unmarshal errors: line N:" framing that yaml.v3
+// adds to type errors, since DecodeError reports the position itself.
+func decodeMessage(err error) string {
+	msg := err.Error()
+	if te, ok := err.(*yaml.TypeError); ok && len(te.Errors) > 0 {
+		msg = te.Errors[0]
+	}
+	if strings.HasPrefix(msg, "line ") {
+		if _, rest, ok := strings.Cut(msg, ": "); ok {
+			msg = rest
+		}
+	}
+	return msg
+}
+
+// suggest returns the known key closest to key, or "" if none is close enough
+// to be a likely typo.
+func suggest(key string, known []string) string {
+	best, bestDist := "", len(key)/3+2
+	for _, k := range known {
+		if strings.EqualFold(k, key) {
+			return k
+		}
+		if d := editDistance(strings.ToLower(key), strings.ToLower(k)); d < bestDist {
+			best, bestDist = k, d
+		}
+	}
+	return best
+}
+
+// editDistance is the Levenshtein distance between a and b.
+func editDistance(a, b string) int {
+	prev := make([]int, len(b)+1)
+	curr := make([]int, len(b)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		curr[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			curr[j] = min(prev[j]+1, curr[j-1]+1, prev[j-1]+cost)
+		}
+		prev, curr = curr, prev
+	}
+	return prev[len(b)]
+}
@@ -0,0 +1,72 @@
+// file: internal/config/strict_test.go
+package config
+
+import (
+	"testing"
+
+	"github.com/cockroachdb/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// loadDecodeErrors loads content as a config file and returns the decode errors it produced.
+func loadDecodeErrors(t *testing.T, content string) DecodeErrors {
+	t.Helper()
+	path := writeFile(t, t.TempDir(), "config.yaml", content)
+	_, _, err := (&Loader{Path: path}).Load()
+	require.Error(t, err)
+	var decodeErrs DecodeErrors
+	require.True(t, errors.As(err, &decodeErrs), "want DecodeErrors, got %v", err)
+	return decodeErrs
+}
+
+// TestLoader_ReportsUnknownKeyWithSuggestion_When_KeyIsMisspelt (ADR-008 Naming)
+func TestLoader_ReportsUnknownKeyWithSuggestion_When_KeyIsMisspelt(t *testing.T) {
+	// Arrange
+	content := "server:\n  port: 9000\n  readTimout: 5s\n"
+
+	// Act
+	errs := loadDecodeErrors(t, content)
+
+	// Assert
+	require.Len(t, errs, 1)
+	assert.Equal(t, 3, errs[0].Line)
+	assert.Equal(t, 3, errs[0].Column)
+	assert.Equal(t, "server.readTimout", errs[0].Path)
+	assert.Equal(t, "readTimeout", errs[0].Suggestion)
+	assert.Contains(t, errs[0].Error(), `did you mean "readTimeout"?`)
+}
+
+// TestLoader_ReportsEveryProblem_When_FileHasDuplicatesAndTypeMismatches (ADR-008 Naming)
+func TestLoader_ReportsEveryProblem_When_FileHasDuplicatesAndTypeMismatches(t *testing.T) {
+	// Arrange
+	content := "server:\n  port: eighty\n  writeTimeout: 5s\n  writeTimeout: 6s\n  responseValidation: sometimes\nmcp: true\nzzz: 1\n"
+
+	// Act
+	errs := loadDecodeErrors(t, content)
+
+	// Assert
+	var got []string
+	for _, e := range errs {
+		got = append(got, e.Path)
+	}
+	assert.Equal(t, []string{"server.port", "server.writeTimeout", "server.responseValidation", "mcp", "zzz"}, got)
+	assert.Equal(t, 2, errs[0].Line)
+	assert.Equal(t, 9, errs[0].Column)
+	assert.Contains(t, errs[1].Message, "first defined at line 3")
+	assert.Empty(t, errs[4].Suggestion, "no suggestion for keys unlike any field")
+}
+
+// TestLoader_ReportsUnknownKeyInFlagRule_When_FeatureFlagIsMisspelt (ADR-008 Naming)
+func TestLoader_ReportsUnknownKeyInFlagRule_When_FeatureFlagIsMisspelt(t *testing.T) {
+	// Arrange
+	content := "featureFlags:\n  flags:\n    new-greeting:\n      enabled: true\n      percentge: 10\n"
+
+	// Act
+	errs := loadDecodeErrors(t, content)
+
+	// Assert
+	require.Len(t, errs, 1)
+	assert.Equal(t, "featureFlags.flags.new-greeting.percentge", errs[0].Path)
+	assert.Equal(t, "percentage", errs[0].Suggestion)
+}
@@ -0,0 +1,88 @@
+// file: internal/config/validate.go
+package config
+
+// validate.go checks the invariants of a resolved configuration.
+
+import (
+	"fmt"
+	"maps"
+	"path"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/dkoosis/hello-tool-base/internal/apperrors"
+	"github.com/dkoosis/hello-tool-base/internal/logging"
+)
+
+// CloudRunTerminationWindow is the time Cloud Run allows between SIGTERM and SIGKILL.
+// A longer graceful timeout would be cut short, so Validate rejects it.
+const CloudRunTerminationWindow = 10 * time.Second
+
+// Validate checks the configuration's invariants. It reports every violation rather
+// than stopping at the first, each with its field path (e.g. "server.gracefulTimeout"),
+// as a single validation error; use apperrors.ViolationsFrom to list them.
+func (c *Config) Validate() error {
+	var violations []apperrors.Violation
+	check := func(ok bool, path, format string, args ...any) {
+		if !ok {
+			violations = append(violations, apperrors.Violation{Field: path, Message: fmt.Sprintf(format, args...)})
+		}
+	}
+
+	s := c.Server
+	check(s.Port > 0 && s.Port <= 65535, "server.port", "must be between 1 and 65535, got %d", s.Port)
+	check(s.ReadTimeout > 0, "server.readTimeout", "must be positive, got %s", s.ReadTimeout)
+	check(s.WriteTimeout > 0, "server.writeTimeout", "must be positive, got %s", s.WriteTimeout)
+	check(s.IdleTimeout >= 0, "server.idleTimeout", "must not be negative, got %s", s.IdleTimeout)
+	check(s.GracefulTimeout > 0, "server.gracefulTimeout", "must be positive, got %s", s.GracefulTimeout)
+	check(s.GracefulTimeout <= CloudRunTerminationWindow, "server.gracefulTimeout",
+		"must not exceed Cloud Run's %s termination window, got %s", CloudRunTerminationWindow, s.GracefulTimeout)
+	switch s.ResponseValidation {
+	case ResponseValidationOff, ResponseValidationLog, ResponseValidationStrict:
+	default:
+		check(false, "server.responseValidation", "must be one of off, log, strict, got %q", s.ResponseValidation)
+	}
+
+	check(strings.HasPrefix(c.MCP.Path, "/"), "mcp.path", "must start with '/', got %q", c.MCP.Path)
+	check(c.MCP.SessionIdleTimeout > 0, "mcp.sessionIdleTimeout", "must be positive, got %s", c.MCP.SessionIdleTimeout)
+	check(c.MCP.MaxSessions > 0, "mcp.maxSessions", "must be positive, got %d", c.MCP.MaxSessions)
+
+	l := c.Logging
+	_, err := logging.ParseLevel(l.Level)
+	check(err == nil, "logging.level", "must be one of debug, info, warn, error, got %q", l.Level)
+	check(l.Format == logging.FormatText || l.Format == logging.FormatJSON || l.Format == logging.FormatCloud,
+		"logging.format", "must be one of text, json, cloud, got %q", l.Format)
+	check(l.Output != "", "logging.output", "must be stderr, stdout or a file path")
+	check(!(c.MCP.Stdio && l.Output == logging.OutputStdout), "logging.output",
+		"must not be stdout when mcp.stdio is set, since stdout carries the protocol")
+	for _, name := range slices.Sorted(maps.Keys(l.Components)) {
+		_, err := logging.ParseLevel(l.Components[name])
+		check(err == nil, "logging.components."+name, "must be one of debug, info, warn, error, got %q", l.Components[name])
+		_, err = path.Match(name, "")
+		check(err == nil, "logging.components."+name, "must be a component name or a valid glob pattern")
+	}
+	check(l.OverrideTTL > 0, "logging.overrideTtl", "must be positive, got %s", l.OverrideTTL)
+	for i, pattern := range l.RedactPatterns {
+		_, err := logging.NewRedactor(nil, []string{pattern})
+		check(err == nil, fmt.Sprintf("logging.redactPatterns[%d]", i), "must be bearer, card, email or a valid regular expression, got %q", pattern)
+	}
+
+	for _, name := range slices.Sorted(maps.Keys(c.FeatureFlags.Flags)) {
+		flag, prefix := c.FeatureFlags.Flags[name], "featureFlags.flags."+name
+		if flag.Percentage != nil {
+			p := *flag.Percentage
+			check(p >= 0 && p <= 100, prefix+".percentage", "must be between 0 and 100, got %g", p)
+		}
+		header, isHeader := strings.CutPrefix(flag.Attribute, "header:")
+		check(flag.Attribute == "" || flag.Attribute == "traceId" || flag.Attribute == "caller" || (isHeader && header != ""),
+			prefix+".attribute", "must be traceId, caller or header:<Name>, got %q", flag.Attribute)
+	}
+
+	if len(violations) == 0 {
+		return nil
+	}
+	return apperrors.NewValidationError(
+		fmt.Sprintf("Config.Validate: %d invalid configuration field(s)", len(violations)),
+		violations, nil)
+}
@@ -0,0 +1,109 @@
+// file: internal/config/validate_test.go
+package config
+
+import (
+	"testing"
+	"time"
+
+	"github.com/dkoosis/hello-tool-base/internal/apperrors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestValidate_ReturnsNil_When_ConfigIsDefault (ADR-008 Naming)
+func TestValidate_ReturnsNil_When_ConfigIsDefault(t *testing.T) {
+	// Arrange
+	cfg := DefaultConfig()
+
+	// Act
+	err := cfg.Validate()
+
+	// Assert
+	assert.NoError(t, err)
+}
+
+// TestValidate_CollectsEveryViolationByPath_When_SeveralFieldsInvalid (ADR-008 Naming)
+func TestValidate_CollectsEveryViolationByPath_When_SeveralFieldsInvalid(t *testing.T) {
+	// Arrange
+	cfg := DefaultConfig()
+	cfg.Server.Port = -1
+	cfg.Server.WriteTimeout = 0
+	cfg.Server.GracefulTimeout = time.Minute
+
+	// Act
+	err := cfg.Validate()
+
+	// Assert
+	require.Error(t, err)
+	var invalidParams *apperrors.InvalidParamsError
+	assert.ErrorAs(t, err, &invalidParams)
+	fields := map[string]string{}
+	for _, v := range apperrors.ViolationsFrom(err) {
+		fields[v.Field] = v.Message
+	}
+	assert.Len(t, fields, 3)
+	assert.Contains(t, fields, "server.port")
+	assert.Contains(t, fields, "server.writeTimeout")
+	assert.Contains(t, fields["server.gracefulTimeout"], "termination window")
+}
+
+// TestLoader_FailsValidation_When_FileSetsNegativePort (ADR-008 Naming)
+func TestLoader_FailsValidation_When_FileSetsNegativePort(t *testing.T) {
+	// Arrange
+	path := writeFile(t, t.TempDir(), "config.yaml", "server:\n  port: -8080\n")
+
+	// Act
+	_, _, err := (&Loader{Path: path}).Load()
+
+	// Assert
+	require.Error(t, err)
+	require.Len(t, apperrors.ViolationsFrom(err), 1)
+	assert.Equal(t, "server.port", apperrors.ViolationsFrom(err)[0].Field)
+}
+
+// TestValidate_ReportsFlagRule_When_PercentageOrAttributeInvalid (ADR-008 Naming)
+func TestValidate_ReportsFlagRule_When_PercentageOrAttributeInvalid(t *testing.T) {
+	// Arrange
+	cfg := DefaultConfig()
+	tooMany, some := 150.0, 10.0
+	cfg.FeatureFlags.Flags = map[string]FeatureFlag{
+		"new-greeting": {Enabled: true, Percentage: &tooMany, Attribute: "header:"},
+		"beta":         {Enabled: true, Percentage: &some, Attribute: "caller"},
+	}
+
+	// Act
+	err := cfg.Validate()
+
+	// Assert
+	require.Error(t, err)
+	var fields []string
+	for _, v := range apperrors.ViolationsFrom(err) {
+		fields = append(fields, v.Field)
+	}
+	assert.Equal(t, []string{"featureFlags.flags.new-greeting.percentage", "featureFlags.flags.new-greeting.attribute"}, fields)
+}
+
+// TestValidate_ReportsLoggingFields_When_LevelsOrOutputInvalid (ADR-008 Naming)
+func TestValidate_ReportsLoggingFields_When_LevelsOrOutputInvalid(t *testing.T) {
+	// Arrange
+	cfg := DefaultConfig()
+	cfg.MCP.Stdio = true
+	cfg.Logging.Level = "verbose"
+	cfg.Logging.Format = "xml"
+	cfg.Logging.Output = "stdout"
+	cfg.Logging.Components = map[string]string{"mcp*": "debug", "tools": "loud", "[": "warn"}
+	cfg.Logging.OverrideTTL = 0
+	cfg.Logging.RedactPatterns = []string{"email", "(unclosed"}
+
+	// Act
+	err := cfg.Validate()
+
+	// Assert
+	require.Error(t, err)
+	var fields []string
+	for _, v := range apperrors.ViolationsFrom(err) {
+		fields = append(fields, v.Field)
+	}
+	assert.Equal(t, []string{"logging.level", "logging.format", "logging.output",
+		"logging.components.[", "logging.components.tools", "logging.overrideTtl", "logging.redactPatterns[1]"}, fields)
+}
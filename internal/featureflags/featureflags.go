@@ -0,0 +1,201 @@
+// Package featureflags evaluates the feature flags defined in the featureFlags section
+// of the configuration. Flags are either on or off for everyone, or rolled out to an
+// allow list and a stable percentage of a request attribute: the trace ID, the caller
+// identity or a header value.
+// file: internal/featureflags/featureflags.go
+package featureflags
+
+import (
+	"context"
+	"hash/fnv"
+	"net/http"
+	"slices"
+	"sort"
+	"strings"
+	"sync"
+	"sync/atomic"
+
+	"github.com/dkoosis/hello-tool-base/internal/config"
+	"github.com/dkoosis/hello-tool-base/internal/logging"
+	"github.com/dkoosis/hello-tool-base/internal/middleware"
+)
+
+// Attribute names accepted by FeatureFlag.Attribute. A header attribute is written
+// AttributeHeaderPrefix followed by the header name, e.g. "header:X-Tenant".
+const (
+	AttributeTraceID      = "traceId"
+	AttributeCaller       = "caller"
+	AttributeHeaderPrefix = "header:"
+)
+
+// Reasons explain an evaluation in logs and in State.
+const (
+	ReasonUnknown          = "unknown flag"
+	ReasonDisabled         = "disabled"
+	ReasonEnabled          = "enabled for everyone"
+	ReasonAllowed          = "allow list"
+	ReasonRollout          = "percentage rollout"
+	ReasonOutsideRollout   = "outside percentage rollout"
+	ReasonMissingAttribute = "attribute missing"
+)
+
+// buckets is the resolution of a percentage rollout: 0.01%.
+const buckets = 10000
+
+// attributesKey is the context key under which Middleware stores the request's attributes.
+type attributesKey struct{}
+
+// attributes are the request values flags may be keyed on, besides the trace ID and
+// the caller, which middleware stores in the context.
+type attributes struct {
+	header http.Header
+}
+
+// Flags evaluates feature flags against the active configuration of a config.Store,
+// so flag changes apply on reload. It is safe for concurrent use.
+type Flags struct {
+	store *config.Store
+	// counts holds an *evaluations per flag name.
+	counts sync.Map
+}
+
+// evaluations counts the results of a flag's evaluations since startup.
+type evaluations struct {
+	on, off atomic.Int64
+}
+
+// New returns Flags that read their rules from store.
+func New(store *config.Store) *Flags {
+	return &Flags{store: store}
+}
+
+// Middleware records the request headers that configured flags are keyed on in the
+// request context; it adds nothing when no flag is keyed on a header. Flags keyed on
+// the caller read the identity stored by middleware.Identity.
+func (f *Flags) Middleware() func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			var header http.Header
+			for _, rule := range f.store.Config().FeatureFlags.Flags {
+				name, ok := strings.CutPrefix(rule.Attribute, AttributeHeaderPrefix)
+				if !ok || len(r.Header.Values(name)) == 0 {
+					continue
+				}
+				if header == nil {
+					header = make(http.Header)
+				}
+				header[http.CanonicalHeaderKey(name)] = slices.Clone(r.Header.Values(name))
+			}
+			if header == nil {
+				next.ServeHTTP(w, r)
+				return
+			}
+			next.ServeHTTP(w, r.WithContext(context.WithValue(r.Context(), attributesKey{}, attributes{header: header})))
+		})
+	}
+}
+
+// Enabled reports whether the flag name is on for the request in ctx, and logs the
+// result and its reason on the request logger. An unknown flag is off. A flag rolled
+// out on an attribute the request does not have is off, unless the rollout is 100%.
+func (f *Flags) Enabled(ctx context.Context, name string) bool {
+	enabled, reason := f.evaluate(ctx, name)
+	f.count(name, enabled)
+	middleware.GetLoggerFromContext(ctx).Debug("Feature flag evaluated.", "flag", name, "enabled", enabled, "reason", reason)
+	return enabled
+}
+
+// evaluate applies the rule of the flag name to the request in ctx.
+func (f *Flags) evaluate(ctx context.Context, name string) (bool, string) {
+	rule, ok := f.store.Config().FeatureFlags.Flags[name]
+	switch {
+	case !ok:
+		return false, ReasonUnknown
+	case !rule.Enabled:
+		return false, ReasonDisabled
+	case len(rule.Allow) == 0 && rule.Percentage == nil:
+		return true, ReasonEnabled
+	}
+
+	var percentage float64
+	if rule.Percentage != nil {
+		percentage = *rule.Percentage
+	}
+	value, ok := attribute(ctx, rule.Attribute)
+	switch {
+	case ok && slices.Contains(rule.Allow, value):
+		return true, ReasonAllowed
+	case percentage >= 100:
+		return true, ReasonRollout
+	case !ok:
+		return false, ReasonMissingAttribute
+	case bucket(name, value) < percentage*buckets/100:
+		return true, ReasonRollout
+	default:
+		return false, ReasonOutsideRollout
+	}
+}
+
+// attribute returns the value of the named request attribute, and whether the request
+// has one. An empty name means the trace ID, without the span ID and options of an
+// X-Cloud-Trace-Context value, so every request of a trace gets the same answer.
+func attribute(ctx context.Context, name string) (string, bool) {
+	var value string
+	attrs, _ := ctx.Value(attributesKey{}).(attributes)
+	switch {
+	case name == "" || name == AttributeTraceID:
+		value, _, _ = logging.ParseTraceContext(middleware.GetTraceIDFromContext(ctx))
+	case name == AttributeCaller:
+		value = middleware.GetCallerFromContext(ctx)
+	case strings.HasPrefix(name, AttributeHeaderPrefix):
+		value = attrs.header.Get(strings.TrimPrefix(name, AttributeHeaderPrefix))
+	}
+	return value, value != ""
+}
+
+// bucket hashes a flag name and attribute value into [0, buckets). Including the
+// name keeps the rollouts of different flags independent of each other.
+func bucket(name, value string) float64 {
+	h := fnv.New32a()
+	_, _ = h.Write([]byte(name + ":" + value))
+	return float64(h.Sum32() % buckets)
+}
+
+// count records one evaluation of the flag name, on or off, for States.
+func (f *Flags) count(name string, enabled bool) {
+	v, _ := f.counts.LoadOrStore(name, &evaluations{})
+	if enabled {
+		v.(*evaluations).on.Add(1)
+	} else {
+		v.(*evaluations).off.Add(1)
+	}
+}
+
+// State is the rule of one configured flag and how often it has evaluated on and off.
+type State struct {
+	Name       string   `json:"name"`
+	Enabled    bool     `json:"enabled"`
+	Percentage *float64 `json:"percentage,omitempty"`
+	Attribute  string   `json:"attribute,omitempty"`
+	Allow      []string `json:"allow,omitempty"`
+	On         int64    `json:"evaluatedOn"`
+	Off        int64    `json:"evaluatedOff"`
+}
+
+// State returns the configured flags, sorted by name.
+func (f *Flags) State() []State {
+	rules := f.store.Config().FeatureFlags.Flags
+	states := make([]State, 0, len(rules))
+	for name, rule := range rules {
+		s := State{Name: name, Enabled: rule.Enabled, Percentage: rule.Percentage, Attribute: rule.Attribute, Allow: rule.Allow}
+		if s.Attribute == "" {
+			s.Attribute = AttributeTraceID
+		}
+		if v, ok := f.counts.Load(name); ok {
+			s.On, s.Off = v.(*evaluations).on.Load(), v.(*evaluations).off.Load()
+		}
+		states = append(states, s)
+	}
+	sort.Slice(states, func(i, j int) bool { return states[i].Name < states[j].Name })
+	return states
+}
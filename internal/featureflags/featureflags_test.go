@@ -0,0 +1,190 @@
+// file: internal/featureflags/featureflags_test.go
+package featureflags
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/dkoosis/hello-tool-base/internal/config"
+	"github.com/dkoosis/hello-tool-base/internal/logging"
+	"github.com/dkoosis/hello-tool-base/internal/middleware"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newFlags returns Flags over a static configuration with the given rules.
+func newFlags(rules map[string]config.FeatureFlag) *Flags {
+	cfg := config.DefaultConfig()
+	cfg.FeatureFlags.Flags = rules
+	return New(config.NewStaticStore(cfg, nil))
+}
+
+// percent returns a pointer to p, for FeatureFlag.Percentage.
+func percent(p float64) *float64 {
+	return &p
+}
+
+// requestContext runs req through Identity and the flags' Middleware with a trace ID and returns
+// the context the handler sees.
+func requestContext(t *testing.T, f *Flags, req *http.Request, traceID string) context.Context {
+	t.Helper()
+	var ctx context.Context
+	var handler http.Handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) { ctx = r.Context() })
+	handler = middleware.Identity("X-Caller-Id", "")(f.Middleware()(handler))
+	req = req.WithContext(middleware.ContextWithTrace(req.Context(), logging.GetNoopLogger(), traceID))
+	handler.ServeHTTP(httptest.NewRecorder(), req)
+	require.NotNil(t, ctx)
+	return ctx
+}
+
+// TestEnabled_FollowsTheRule_When_FlagIsConfigured (ADR-008 Naming)
+func TestEnabled_FollowsTheRule_When_FlagIsConfigured(t *testing.T) {
+	req := httptest.NewRequest(http.MethodGet, "/hello", nil)
+	req.Header.Set("X-Caller-Id", "agent-a")
+	req.Header.Set("X-Tenant", "acme")
+
+	tests := []struct {
+		name string
+		rule config.FeatureFlag
+		want bool
+	}{
+		{"disabled", config.FeatureFlag{Enabled: false}, false},
+		{"enabled for everyone", config.FeatureFlag{Enabled: true}, true},
+		{"caller allowed", config.FeatureFlag{Enabled: true, Attribute: "caller", Allow: []string{"agent-a"}}, true},
+		{"caller not allowed", config.FeatureFlag{Enabled: true, Attribute: "caller", Allow: []string{"agent-b"}}, false},
+		{"header allowed", config.FeatureFlag{Enabled: true, Attribute: "header:X-Tenant", Allow: []string{"acme"}}, true},
+		{"full rollout", config.FeatureFlag{Enabled: true, Percentage: percent(100)}, true},
+		{"explicit zero rollout", config.FeatureFlag{Enabled: true, Percentage: percent(0)}, false},
+		{"explicit zero rollout with caller allowed", config.FeatureFlag{Enabled: true, Percentage: percent(0), Attribute: "caller", Allow: []string{"agent-a"}}, true},
+		{"missing header", config.FeatureFlag{Enabled: true, Attribute: "header:X-Missing", Percentage: percent(99.99)}, false},
+		{"disabled despite allow list", config.FeatureFlag{Enabled: false, Attribute: "caller", Allow: []string{"agent-a"}}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			f := newFlags(map[string]config.FeatureFlag{"new-greeting": tt.rule})
+			ctx := requestContext(t, f, req, "trace-1")
+
+			// Act
+			got := f.Enabled(ctx, "new-greeting")
+
+			// Assert
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+// TestEnabled_ReturnsFalse_When_FlagIsUnknown (ADR-008 Naming)
+func TestEnabled_ReturnsFalse_When_FlagIsUnknown(t *testing.T) {
+	// Arrange
+	f := newFlags(nil)
+
+	// Act
+	got := f.Enabled(context.Background(), "no-such-flag")
+
+	// Assert
+	assert.False(t, got)
+}
+
+// TestEnabled_IsStableAndProportional_When_FlagIsAPercentageRollout (ADR-008 Naming)
+func TestEnabled_IsStableAndProportional_When_FlagIsAPercentageRollout(t *testing.T) {
+	// Arrange
+	f := newFlags(map[string]config.FeatureFlag{"new-greeting": {Enabled: true, Percentage: percent(25)}})
+	req := httptest.NewRequest(http.MethodGet, "/hello", nil)
+	const requests = 4000
+
+	// Act
+	on := 0
+	for i := 0; i < requests; i++ {
+		ctx := requestContext(t, f, req, fmt.Sprintf("trace-%d", i))
+		first := f.Enabled(ctx, "new-greeting")
+		require.Equal(t, first, f.Enabled(ctx, "new-greeting"), "evaluation must be stable for trace-%d", i)
+		if first {
+			on++
+		}
+	}
+
+	// Assert
+	assert.InDelta(t, 0.25, float64(on)/requests, 0.03)
+}
+
+// TestEnabled_GivesSameAnswer_When_RequestsShareATrace (ADR-008 Naming)
+func TestEnabled_GivesSameAnswer_When_RequestsShareATrace(t *testing.T) {
+	// Arrange
+	f := newFlags(map[string]config.FeatureFlag{"new-greeting": {Enabled: true, Percentage: percent(50)}})
+	req := httptest.NewRequest(http.MethodGet, "/hello", nil)
+
+	for i := 0; i < 50; i++ {
+		trace := fmt.Sprintf("%032x", i)
+
+		// Act
+		first := f.Enabled(requestContext(t, f, req, trace+"/1;o=1"), "new-greeting")
+		second := f.Enabled(requestContext(t, f, req, trace+"/2;o=0"), "new-greeting")
+
+		// Assert
+		require.Equal(t, first, second, "spans of trace %s must get the same answer", trace)
+	}
+}
+
+// TestMiddleware_LeavesContextUnchanged_When_NoFlagIsKeyedOnAHeader (ADR-008 Naming)
+func TestMiddleware_LeavesContextUnchanged_When_NoFlagIsKeyedOnAHeader(t *testing.T) {
+	// Arrange
+	f := newFlags(map[string]config.FeatureFlag{"beta": {Enabled: true, Attribute: "caller", Allow: []string{"agent-a"}}})
+	req := httptest.NewRequest(http.MethodGet, "/hello", nil)
+	req.Header.Set("X-Tenant", "acme")
+
+	// Act
+	ctx := requestContext(t, f, req, "trace-1")
+
+	// Assert
+	assert.Nil(t, ctx.Value(attributesKey{}))
+}
+
+// TestState_ReportsRulesAndCounts_When_FlagsWereEvaluated (ADR-008 Naming)
+func TestState_ReportsRulesAndCounts_When_FlagsWereEvaluated(t *testing.T) {
+	// Arrange
+	f := newFlags(map[string]config.FeatureFlag{
+		"new-greeting": {Enabled: true},
+		"beta":         {Enabled: false, Attribute: "caller", Allow: []string{"agent-a"}},
+	})
+	ctx := context.Background()
+	f.Enabled(ctx, "new-greeting")
+	f.Enabled(ctx, "new-greeting")
+	f.Enabled(ctx, "beta")
+
+	// Act
+	states := f.State()
+
+	// Assert
+	assert.Equal(t, []State{
+		{Name: "beta", Enabled: false, Attribute: "caller", Allow: []string{"agent-a"}, On: 0, Off: 1},
+		{Name: "new-greeting", Enabled: true, Attribute: "traceId", On: 2, Off: 0},
+	}, states)
+}
+
+// TestEnabled_UsesReloadedRule_When_StoreConfigChanges (ADR-008 Naming)
+func TestEnabled_UsesReloadedRule_When_StoreConfigChanges(t *testing.T) {
+	// Arrange
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	writeConfig := func(enabled bool) {
+		data := fmt.Sprintf("featureFlags:\n  flags:\n    new-greeting:\n      enabled: %t\n", enabled)
+		require.NoError(t, os.WriteFile(path, []byte(data), 0o600))
+	}
+	writeConfig(false)
+	store, err := config.NewStore(&config.Loader{Path: path})
+	require.NoError(t, err)
+	f := New(store)
+	require.False(t, f.Enabled(context.Background(), "new-greeting"))
+	writeConfig(true)
+
+	// Act
+	require.NoError(t, store.Reload())
+
+	// Assert
+	assert.True(t, f.Enabled(context.Background(), "new-greeting"))
+}
@@ -0,0 +1,128 @@
+// file: internal/jsonrpc/http.go
+package jsonrpc
+
+// http.go serves JSON-RPC 2.0 over HTTP, including batches.
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"sync"
+
+	"github.com/cockroachdb/errors"
+
+	"github.com/dkoosis/hello-tool-base/internal/apperrors"
+	"github.com/dkoosis/hello-tool-base/internal/logging"
+	"github.com/dkoosis/hello-tool-base/internal/middleware"
+)
+
+// MaxBodySize is the largest request body, in bytes, the HTTP handler accepts.
+const MaxBodySize = 4 << 20
+
+// MaxBatchSize is the largest number of calls accepted in one batch.
+const MaxBatchSize = 50
+
+// NewHTTPHandler serves JSON-RPC 2.0 over HTTP POST, dispatching to h.
+//
+// The body may be a single request or a batch array. Every call keeps the request's
+// trace ID. Batch calls run concurrently, each with a logger derived from baseLogger
+// that also carries the call's zero-based position in the batch as batch_index.
+//
+// Responses use status 200, including JSON-RPC errors; a message consisting only of
+// notifications is answered with 204 No Content. A body larger than MaxBodySize is
+// answered with an invalid request error naming the limit. It expects the Tracing
+// middleware to have populated the request context.
+func NewHTTPHandler(h Handler, baseLogger logging.Logger) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			w.Header().Set("Allow", http.MethodPost)
+			http.Error(w, "JSON-RPC endpoint accepts POST only", http.StatusMethodNotAllowed)
+			return
+		}
+		logger := middleware.GetLoggerFromContext(r.Context())
+
+		body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, MaxBodySize))
+		var tooLarge *http.MaxBytesError
+		if errors.As(err, &tooLarge) {
+			writeJSON(w, logger, NewError(nil, apperrors.NewInvalidRequestError(
+				fmt.Sprintf("jsonrpc: request body exceeds the limit of %d bytes", MaxBodySize), err,
+				map[string]interface{}{"limit": MaxBodySize})))
+			return
+		}
+		if err != nil {
+			writeJSON(w, logger, NewError(nil, apperrors.NewParseError("jsonrpc: failed to read request body", err, nil)))
+			return
+		}
+
+		trimmed := bytes.TrimLeft(body, " \t\r\n")
+		if len(trimmed) == 0 || trimmed[0] != '[' {
+			if resp := Dispatch(r.Context(), h, body); resp != nil {
+				writeJSON(w, logger, resp)
+				return
+			}
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+
+		var batch []json.RawMessage
+		if err := json.Unmarshal(body, &batch); err != nil {
+			writeJSON(w, logger, NewError(nil, apperrors.NewParseError("jsonrpc: batch is not valid JSON", err, nil)))
+			return
+		}
+		switch {
+		case len(batch) == 0:
+			writeJSON(w, logger, NewError(nil, apperrors.NewInvalidRequestError("jsonrpc: batch must not be empty", nil, nil)))
+			return
+		case len(batch) > MaxBatchSize:
+			writeJSON(w, logger, NewError(nil, apperrors.NewInvalidRequestError(
+				fmt.Sprintf("jsonrpc: batch of %d calls exceeds the limit of %d", len(batch), MaxBatchSize), nil, nil)))
+			return
+		}
+
+		logger.Info("Dispatching JSON-RPC batch.", "calls", len(batch))
+		responses := dispatchBatch(r.Context(), h, baseLogger, batch)
+		if len(responses) == 0 {
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+		writeJSON(w, logger, responses)
+	})
+}
+
+// dispatchBatch handles the calls of a batch concurrently and returns the responses
+// in call order, omitting notifications.
+func dispatchBatch(ctx context.Context, h Handler, baseLogger logging.Logger, batch []json.RawMessage) []*Response {
+	traceID := middleware.GetTraceIDFromContext(ctx)
+
+	results := make([]*Response, len(batch))
+	var wg sync.WaitGroup
+	for i, msg := range batch {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			callCtx := middleware.ContextWithTrace(ctx, baseLogger.WithField("batch_index", i), traceID)
+			results[i] = Dispatch(callCtx, h, msg)
+		}()
+	}
+	wg.Wait()
+
+	responses := make([]*Response, 0, len(results))
+	for _, resp := range results {
+		if resp != nil {
+			responses = append(responses, resp)
+		}
+	}
+	return responses
+}
+
+// writeJSON writes v as a 200 application/json response.
+func writeJSON(w http.ResponseWriter, logger logging.Logger, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	if err := json.NewEncoder(w).Encode(v); err != nil {
+		logger.Error("Failed to encode JSON-RPC response.", "error", fmt.Sprintf("%+v", err))
+	}
+}
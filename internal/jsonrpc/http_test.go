@@ -0,0 +1,141 @@
+// file: internal/jsonrpc/http_test.go
+package jsonrpc
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dkoosis/hello-tool-base/internal/apperrors"
+	"github.com/dkoosis/hello-tool-base/internal/logging"
+	"github.com/dkoosis/hello-tool-base/internal/logging/logtest"
+	"github.com/dkoosis/hello-tool-base/internal/middleware"
+)
+
+// traceEcho answers "trace" with the caller's trace ID, logging it, and fails
+// everything else.
+var traceEcho = HandlerFunc(func(ctx context.Context, req *Request) (any, error) {
+	if req.Method != "trace" {
+		return nil, apperrors.NewMethodNotFoundError("no method "+req.Method, nil, nil)
+	}
+	middleware.GetLoggerFromContext(ctx).Info("Traced.")
+	return middleware.GetTraceIDFromContext(ctx), nil
+})
+
+func postRPC(t *testing.T, body string) *httptest.ResponseRecorder {
+	t.Helper()
+	handler := middleware.Tracing(logging.GetNoopLogger())(NewHTTPHandler(traceEcho, logging.GetNoopLogger()))
+	req := httptest.NewRequest(http.MethodPost, "/rpc", strings.NewReader(body))
+	req.Header.Set(middleware.HeaderCloudTraceContext, "req-trace")
+	rr := httptest.NewRecorder()
+	handler.ServeHTTP(rr, req)
+	return rr
+}
+
+// TestHTTPHandler_AnswersEachCallInOrder_When_BodyIsBatch (ADR-008 Naming)
+func TestHTTPHandler_AnswersEachCallInOrder_When_BodyIsBatch(t *testing.T) {
+	// Arrange
+	body := `[
+		{"jsonrpc":"2.0","id":1,"method":"trace"},
+		{"jsonrpc":"2.0","method":"trace"},
+		{"jsonrpc":"2.0","id":"b","method":"missing"},
+		{"jsonrpc":"1.0","id":3,"method":"trace"},
+		{"jsonrpc":"2.0","id":4,"method":"trace"}
+	]`
+
+	// Act
+	rr := postRPC(t, body)
+
+	// Assert
+	require.Equal(t, http.StatusOK, rr.Code)
+	var responses []Response
+	require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &responses))
+	require.Len(t, responses, 4, "the notification must not be answered")
+	assert.JSONEq(t, `"req-trace"`, string(responses[0].Result))
+	assert.Equal(t, int(apperrors.ErrMethodNotFound), responses[1].Error.Code)
+	assert.JSONEq(t, `"b"`, string(responses[1].ID))
+	assert.Equal(t, int(apperrors.ErrInvalidRequest), responses[2].Error.Code)
+	assert.JSONEq(t, `"req-trace"`, string(responses[3].Result))
+}
+
+// TestHTTPHandler_LogsBatchIndexUnderRequestTrace_When_BodyIsBatch (ADR-008 Naming)
+func TestHTTPHandler_LogsBatchIndexUnderRequestTrace_When_BodyIsBatch(t *testing.T) {
+	// Arrange
+	log := logtest.New()
+	handler := middleware.Tracing(logging.GetNoopLogger())(NewHTTPHandler(traceEcho, log))
+	req := httptest.NewRequest(http.MethodPost, "/rpc", strings.NewReader(
+		`[{"jsonrpc":"2.0","id":1,"method":"trace"},{"jsonrpc":"2.0","id":2,"method":"trace"}]`))
+	req.Header.Set(middleware.HeaderCloudTraceContext, "105445aa7843bc8bf206b12000100000/7;o=1")
+
+	// Act
+	handler.ServeHTTP(httptest.NewRecorder(), req)
+
+	// Assert
+	entries := log.ForTrace("105445aa7843bc8bf206b12000100000")
+	require.Len(t, entries, 2)
+	for i := range 2 {
+		log.AssertLogged(t, slog.LevelInfo, "Traced.",
+			"batch_index", i, logging.TraceIDKey, "105445aa7843bc8bf206b12000100000/7;o=1")
+	}
+}
+
+// TestHTTPHandler_ReturnsSingleError_When_BodyIsNotAValidBatch (ADR-008 Naming)
+func TestHTTPHandler_ReturnsSingleError_When_BodyIsNotAValidBatch(t *testing.T) {
+	cases := map[string]struct {
+		body string
+		code apperrors.ErrorCode
+	}{
+		"malformed JSON":  {body: `{"jsonrpc":"2.0","method"`, code: apperrors.ErrParseError},
+		"malformed batch": {body: `[{"jsonrpc":"2.0","method":"trace"},`, code: apperrors.ErrParseError},
+		"empty batch":     {body: `[]`, code: apperrors.ErrInvalidRequest},
+		"not an object":   {body: `42`, code: apperrors.ErrInvalidRequest},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			// Act
+			rr := postRPC(t, tc.body)
+
+			// Assert
+			require.Equal(t, http.StatusOK, rr.Code)
+			var resp Response
+			require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &resp))
+			require.NotNil(t, resp.Error)
+			assert.Equal(t, int(tc.code), resp.Error.Code)
+			assert.Equal(t, "null", string(resp.ID))
+		})
+	}
+}
+
+// TestHTTPHandler_ReturnsSizeError_When_BodyExceedsMaxBodySize (ADR-008 Naming)
+func TestHTTPHandler_ReturnsSizeError_When_BodyExceedsMaxBodySize(t *testing.T) {
+	// Arrange
+	body := `{"jsonrpc":"2.0","id":1,"method":"trace","params":"` + strings.Repeat("x", MaxBodySize) + `"}`
+
+	// Act
+	rr := postRPC(t, body)
+
+	// Assert
+	require.Equal(t, http.StatusOK, rr.Code)
+	var resp Response
+	require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &resp))
+	require.NotNil(t, resp.Error)
+	assert.Equal(t, int(apperrors.ErrInvalidRequest), resp.Error.Code)
+	assert.Contains(t, resp.Error.Data["detail"], "exceeds the limit of 4194304 bytes")
+}
+
+// TestHTTPHandler_ReturnsNoContent_When_BatchHasOnlyNotifications (ADR-008 Naming)
+func TestHTTPHandler_ReturnsNoContent_When_BatchHasOnlyNotifications(t *testing.T) {
+	// Act
+	rr := postRPC(t, `[{"jsonrpc":"2.0","method":"trace"},{"jsonrpc":"2.0","method":"missing"}]`)
+
+	// Assert
+	assert.Equal(t, http.StatusNoContent, rr.Code)
+	assert.Empty(t, rr.Body.String())
+}
@@ -0,0 +1,142 @@
+// Package jsonrpc implements JSON-RPC 2.0 message types and dispatch shared by the
+// protocols the service speaks (MCP, and plain JSON-RPC over HTTP). Errors returned by
+// method handlers are translated with apperrors.MapAppErrorToJSONRPC, so every transport
+// reports failures with the same codes.
+// file: internal/jsonrpc/jsonrpc.go
+package jsonrpc
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/cockroachdb/errors"
+	"github.com/dkoosis/hello-tool-base/internal/apperrors"
+)
+
+// Version is the only JSON-RPC protocol version accepted.
+const Version = "2.0"
+
+// Request is a JSON-RPC request or, when ID is absent, a notification.
+type Request struct {
+	JSONRPC string          `json:"jsonrpc"`
+	ID      json.RawMessage `json:"id,omitempty"`
+	Method  string          `json:"method"`
+	Params  json.RawMessage `json:"params,omitempty"`
+}
+
+// IsNotification reports whether the request has no ID and therefore expects no response.
+func (r *Request) IsNotification() bool {
+	return len(r.ID) == 0
+}
+
+// Response is a JSON-RPC response. Exactly one of Result and Error is set.
+// A nil ID marshals as null, as required when the request ID could not be determined.
+type Response struct {
+	JSONRPC string          `json:"jsonrpc"`
+	ID      json.RawMessage `json:"id"`
+	Result  json.RawMessage `json:"result,omitempty"`
+	Error   *Error          `json:"error,omitempty"`
+}
+
+// Error is the error object of a JSON-RPC response.
+type Error struct {
+	Code    int                    `json:"code"`
+	Message string                 `json:"message"`
+	Data    map[string]interface{} `json:"data,omitempty"`
+}
+
+// Error implements the error interface, so clients can return response errors directly.
+func (e *Error) Error() string {
+	return fmt.Sprintf("JSON-RPC error %d: %s", e.Code, e.Message)
+}
+
+// Handler processes a single decoded request and returns its result or an error.
+// It is called for notifications too; their results are discarded.
+type Handler interface {
+	HandleRPC(ctx context.Context, req *Request) (any, error)
+}
+
+// HandlerFunc adapts a function to the Handler interface.
+type HandlerFunc func(ctx context.Context, req *Request) (any, error)
+
+// HandleRPC calls f(ctx, req).
+func (f HandlerFunc) HandleRPC(ctx context.Context, req *Request) (any, error) {
+	return f(ctx, req)
+}
+
+// Decode parses a single JSON-RPC request. Malformed JSON yields an apperrors parse
+// error (-32700); well-formed JSON that is not a valid request object yields an
+// invalid request error (-32600).
+func Decode(data []byte) (*Request, error) {
+	var req Request
+	if err := json.Unmarshal(data, &req); err != nil {
+		if !json.Valid(data) {
+			return nil, apperrors.NewParseError("jsonrpc.Decode: message is not valid JSON", err, nil)
+		}
+		return nil, apperrors.NewInvalidRequestError("jsonrpc.Decode: message is not a request object", err, nil)
+	}
+	if req.JSONRPC != Version {
+		return &req, apperrors.NewInvalidRequestError("jsonrpc.Decode: jsonrpc member must be exactly \"2.0\"", nil, nil)
+	}
+	if req.Method == "" {
+		return &req, apperrors.NewInvalidRequestError("jsonrpc.Decode: method member is required", nil, nil)
+	}
+	if len(req.ID) > 0 {
+		var id any
+		_ = json.Unmarshal(req.ID, &id) // Already known to be valid JSON.
+		switch id.(type) {
+		case string, float64, nil:
+		default:
+			req.ID = nil // An unusable ID is answered with a null ID.
+			return &req, apperrors.NewInvalidRequestError("jsonrpc.Decode: id must be a string, number or null", nil, nil)
+		}
+	}
+	return &req, nil
+}
+
+// NewResult builds a success response for id.
+func NewResult(id json.RawMessage, result any) (*Response, error) {
+	data, err := json.Marshal(result)
+	if err != nil {
+		return nil, errors.Wrap(err, "jsonrpc.NewResult: failed to marshal result")
+	}
+	return &Response{JSONRPC: Version, ID: id, Result: data}, nil
+}
+
+// NewError builds an error response for id, mapping err with apperrors.MapAppErrorToJSONRPC.
+func NewError(id json.RawMessage, err error) *Response {
+	code, message, data := apperrors.MapAppErrorToJSONRPC(err)
+	return &Response{JSONRPC: Version, ID: id, Error: &Error{Code: code, Message: message, Data: data}}
+}
+
+// Dispatch decodes and handles a single message. It returns nil for notifications,
+// which never receive a response, not even on error.
+func Dispatch(ctx context.Context, h Handler, data []byte) *Response {
+	req, err := Decode(data)
+	if err != nil {
+		var id json.RawMessage
+		if req != nil {
+			id = req.ID
+		}
+		return NewError(id, err)
+	}
+	return Call(ctx, h, req)
+}
+
+// Call invokes h for an already decoded request and builds its response.
+// It returns nil for notifications.
+func Call(ctx context.Context, h Handler, req *Request) *Response {
+	result, err := h.HandleRPC(ctx, req)
+	if req.IsNotification() {
+		return nil
+	}
+	if err != nil {
+		return NewError(req.ID, err)
+	}
+	resp, err := NewResult(req.ID, result)
+	if err != nil {
+		return NewError(req.ID, apperrors.NewInternalError("jsonrpc.Call: failed to encode result", err, map[string]interface{}{"method": req.Method}))
+	}
+	return resp
+}
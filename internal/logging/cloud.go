@@ -0,0 +1,231 @@
+// file: internal/logging/cloud.go
+package logging
+
+// cloud.go implements the Cloud Logging structured JSON format, so that on Cloud Run
+// each line is parsed into a jsonPayload with its severity and linked to its trace.
+// See https://cloud.google.com/logging/docs/structured-logging.
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// TraceIDKey is the attribute holding a request's trace ID, which middleware.Tracing
+// stores in the request context. Its value is the X-Cloud-Trace-Context header when
+// the request carried one: "TRACE_ID/SPAN_ID;o=OPTIONS".
+const TraceIDKey = "trace_id"
+
+// HTTPRequestKey is the attribute Cloud Logging reads an HTTPRequest from.
+const HTTPRequestKey = "httpRequest"
+
+// Special fields of the Cloud Logging structured format.
+const (
+	cloudMessageKey  = "message"
+	cloudSeverityKey = "severity"
+	cloudSourceKey   = "logging.googleapis.com/sourceLocation"
+	cloudTraceKey    = "logging.googleapis.com/trace"
+	cloudSpanKey     = "logging.googleapis.com/spanId"
+	cloudSampledKey  = "logging.googleapis.com/trace_sampled"
+)
+
+// CloudHandlerOptions configures NewCloudHandler.
+type CloudHandlerOptions struct {
+	// ProjectID qualifies trace IDs as "projects/<ProjectID>/traces/<id>", the form
+	// Cloud Logging links to Cloud Trace. Without it trace IDs are written as is.
+	ProjectID string
+	// AddSource adds the logging.googleapis.com/sourceLocation of the logging call.
+	AddSource bool
+	// Level is the minimum level handled; nil means info.
+	Level slog.Leveler
+}
+
+// cloudHandler writes records as Cloud Logging structured JSON. It wraps a
+// slog.JSONHandler, renaming the level and message, expands the TraceIDKey attribute
+// into the trace and sampling fields, and renames SpanIDKey to the span field.
+type cloudHandler struct {
+	json      slog.Handler
+	projectID string
+	// grouped is set once WithGroup has been called; attributes are then nested and
+	// a trace ID among them is left as it is.
+	grouped bool
+}
+
+// NewCloudHandler returns a handler writing one Cloud Logging JSON object per record to w.
+func NewCloudHandler(w io.Writer, opts CloudHandlerOptions) slog.Handler {
+	return &cloudHandler{
+		json: slog.NewJSONHandler(w, &slog.HandlerOptions{
+			AddSource:   opts.AddSource,
+			Level:       opts.Level,
+			ReplaceAttr: replaceCloudAttr,
+		}),
+		projectID: opts.ProjectID,
+	}
+}
+
+// Enabled reports whether the wrapped JSON handler handles level.
+func (h *cloudHandler) Enabled(ctx context.Context, level slog.Level) bool {
+	return h.json.Enabled(ctx, level)
+}
+
+// Handle writes r, replacing trace attributes with the Cloud Logging trace fields.
+func (h *cloudHandler) Handle(ctx context.Context, r slog.Record) error {
+	if h.grouped || !hasTraceAttr(r) {
+		return h.json.Handle(ctx, r)
+	}
+	out := slog.NewRecord(r.Time, r.Level, r.Message, r.PC)
+	r.Attrs(func(a slog.Attr) bool {
+		out.AddAttrs(h.expandTrace(a)...)
+		return true
+	})
+	return h.json.Handle(ctx, out)
+}
+
+// WithAttrs adds attrs to every record, replacing trace attributes.
+func (h *cloudHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	if h.grouped {
+		return &cloudHandler{json: h.json.WithAttrs(attrs), projectID: h.projectID, grouped: true}
+	}
+	expanded := make([]slog.Attr, 0, len(attrs))
+	for _, a := range attrs {
+		expanded = append(expanded, h.expandTrace(a)...)
+	}
+	return &cloudHandler{json: h.json.WithAttrs(expanded), projectID: h.projectID}
+}
+
+// WithGroup nests later attributes under name.
+func (h *cloudHandler) WithGroup(name string) slog.Handler {
+	if name == "" {
+		return h
+	}
+	return &cloudHandler{json: h.json.WithGroup(name), projectID: h.projectID, grouped: true}
+}
+
+func hasTraceAttr(r slog.Record) bool {
+	found := false
+	r.Attrs(func(a slog.Attr) bool {
+		found = a.Key == TraceIDKey || a.Key == SpanIDKey
+		return !found
+	})
+	return found
+}
+
+// expandTrace returns the Cloud Logging fields for a trace ID or span ID attribute,
+// and any other attribute unchanged.
+func (h *cloudHandler) expandTrace(a slog.Attr) []slog.Attr {
+	switch a.Key {
+	case SpanIDKey:
+		return []slog.Attr{{Key: cloudSpanKey, Value: a.Value}}
+	case TraceIDKey:
+		trace, _, sampled := ParseTraceContext(a.Value.String())
+		if trace == "" {
+			return nil
+		}
+		if h.projectID != "" {
+			trace = "projects/" + h.projectID + "/traces/" + trace
+		}
+		return []slog.Attr{slog.String(cloudTraceKey, trace), slog.Bool(cloudSampledKey, sampled)}
+	}
+	return []slog.Attr{a}
+}
+
+// ParseTraceContext splits an X-Cloud-Trace-Context value, "TRACE_ID/SPAN_ID;o=1",
+// into the trace ID, the span ID as the 16-digit hex Cloud Logging expects, and
+// whether the trace is sampled. A value without a span, such as a generated trace ID,
+// is returned whole as the trace ID.
+func ParseTraceContext(value string) (trace, span string, sampled bool) {
+	value, options, _ := strings.Cut(value, ";")
+	trace, span, _ = strings.Cut(value, "/")
+	if id, err := strconv.ParseUint(span, 10, 64); err == nil {
+		span = fmt.Sprintf("%016x", id)
+	}
+	return trace, span, options == "o=1"
+}
+
+// replaceCloudAttr renames slog's built-in attributes to their Cloud Logging fields.
+func replaceCloudAttr(groups []string, a slog.Attr) slog.Attr {
+	if len(groups) > 0 {
+		return a
+	}
+	switch a.Key {
+	case slog.LevelKey:
+		level, _ := a.Value.Any().(slog.Level)
+		return slog.String(cloudSeverityKey, severity(level))
+	case slog.MessageKey:
+		return slog.Attr{Key: cloudMessageKey, Value: a.Value}
+	case slog.SourceKey:
+		src, ok := a.Value.Any().(*slog.Source)
+		if !ok || src == nil {
+			return a
+		}
+		return slog.Group(cloudSourceKey,
+			slog.String("file", src.File),
+			slog.String("line", strconv.Itoa(src.Line)),
+			slog.String("function", src.Function))
+	}
+	return a
+}
+
+// severity maps a slog level to a Cloud Logging LogSeverity.
+func severity(level slog.Level) string {
+	switch {
+	case level < slog.LevelInfo:
+		return "DEBUG"
+	case level < slog.LevelWarn:
+		return "INFO"
+	case level < slog.LevelError:
+		return "WARNING"
+	default:
+		return "ERROR"
+	}
+}
+
+// HTTPRequest describes a completed HTTP request for access logs. Logged under
+// HTTPRequestKey, Cloud Logging shows it as the entry's request and links the entry
+// in the request log viewer. Zero fields are omitted.
+type HTTPRequest struct {
+	Method       string
+	URL          string
+	Status       int
+	RequestSize  int64
+	ResponseSize int64
+	UserAgent    string
+	RemoteIP     string
+	Referer      string
+	Protocol     string
+	Latency      time.Duration
+}
+
+// LogValue renders the request with the field names of Cloud Logging's HttpRequest.
+func (r HTTPRequest) LogValue() slog.Value {
+	attrs := make([]slog.Attr, 0, 10)
+	add := func(key, value string) {
+		if value != "" {
+			attrs = append(attrs, slog.String(key, value))
+		}
+	}
+	add("requestMethod", r.Method)
+	add("requestUrl", r.URL)
+	if r.Status != 0 {
+		attrs = append(attrs, slog.Int("status", r.Status))
+	}
+	// int64 fields are strings in the JSON form of the LogEntry API.
+	if r.RequestSize != 0 {
+		add("requestSize", strconv.FormatInt(r.RequestSize, 10))
+	}
+	if r.ResponseSize != 0 {
+		add("responseSize", strconv.FormatInt(r.ResponseSize, 10))
+	}
+	add("userAgent", r.UserAgent)
+	add("remoteIp", r.RemoteIP)
+	add("referer", r.Referer)
+	add("protocol", r.Protocol)
+	if r.Latency != 0 {
+		add("latency", strconv.FormatFloat(r.Latency.Seconds(), 'f', 9, 64)+"s")
+	}
+	return slog.GroupValue(attrs...)
+}
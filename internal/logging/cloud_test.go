@@ -0,0 +1,103 @@
+// file: internal/logging/cloud_test.go
+package logging
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"flag"
+	"log/slog"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// cloudGoldenPath holds the expected output of the cloud handler, one JSON object per line.
+const cloudGoldenPath = "testdata/cloud.golden.jsonl"
+
+// updateGolden regenerates the golden files instead of comparing against them.
+var updateGolden = flag.Bool("update", false, "rewrite testdata/*.golden.jsonl from the handlers")
+
+// TestCloudHandler_MatchesGoldenFile_When_RecordsCoverEveryMapping (ADR-008 Naming)
+func TestCloudHandler_MatchesGoldenFile_When_RecordsCoverEveryMapping(t *testing.T) {
+	// Arrange
+	var out bytes.Buffer
+	at := time.Date(2025, 5, 17, 9, 30, 0, 123456789, time.UTC)
+	handler := NewCloudHandler(&out, CloudHandlerOptions{ProjectID: "demo-project", Level: slog.LevelDebug})
+	record := func(level slog.Level, msg string, attrs ...slog.Attr) slog.Record {
+		r := slog.NewRecord(at, level, msg, 0)
+		r.AddAttrs(attrs...)
+		return r
+	}
+	ctx := context.Background()
+	component := handler.WithAttrs([]slog.Attr{slog.String("component", "hello-tool")})
+	sampled := component.WithAttrs([]slog.Attr{
+		slog.String(TraceIDKey, "105445aa7843bc8bf206b12000100000/1;o=1"),
+		slog.String(SpanIDKey, "0000000000000001"),
+	})
+	generated := component.WithAttrs([]slog.Attr{slog.String(TraceIDKey, "generated-0f8fad5b-d9cb-469f-a165-70867728950e")})
+	cases := []struct {
+		handler slog.Handler
+		record  slog.Record
+	}{
+		{handler, record(slog.LevelDebug, "Debug without trace.")},
+		{component, record(slog.LevelInfo, "Service starting...", slog.Int("port", 8080))},
+		{sampled, record(slog.LevelWarn, "Path not found", slog.String("path", "/missing"))},
+		{generated, record(slog.LevelError, "Failed to write response", slog.String("error", "broken pipe"))},
+		{component, record(slog.LevelInfo, "Trace on the record.", slog.String(TraceIDKey, "abc123/7;o=0"), slog.String(SpanIDKey, "0000000000000007"))},
+		{sampled, record(slog.LevelInfo, "Request completed.", slog.Any(HTTPRequestKey, HTTPRequest{
+			Method: "GET", URL: "/hello?name=Ada", Status: 200, ResponseSize: 58,
+			UserAgent: "curl/8.5.0", RemoteIP: "203.0.113.7:52100", Protocol: "HTTP/1.1", Latency: 1500 * time.Microsecond,
+		}))},
+		{sampled.WithGroup("request"), record(slog.LevelInfo, "Grouped attributes stay nested.", slog.String(TraceIDKey, "nested"))},
+		{handler, record(slog.LevelError+4, "Above error.")},
+	}
+
+	// Act
+	for _, c := range cases {
+		require.NoError(t, c.handler.Handle(ctx, c.record))
+	}
+
+	// Assert
+	if *updateGolden {
+		require.NoError(t, os.WriteFile(cloudGoldenPath, out.Bytes(), 0o644))
+		return
+	}
+	golden, err := os.ReadFile(cloudGoldenPath)
+	require.NoError(t, err)
+	assert.Equal(t, string(golden), out.String(),
+		"cloud handler output changed; if intended, run: go test ./internal/logging -run TestCloudHandler -update")
+}
+
+// TestCloudHandler_AddsSourceLocation_When_AddSourceSet (ADR-008 Naming)
+func TestCloudHandler_AddsSourceLocation_When_AddSourceSet(t *testing.T) {
+	// Arrange
+	var out bytes.Buffer
+	logger := slog.New(NewCloudHandler(&out, CloudHandlerOptions{AddSource: true}))
+
+	// Act
+	logger.Info("With source.")
+
+	// Assert
+	var entry map[string]any
+	require.NoError(t, json.Unmarshal(out.Bytes(), &entry))
+	source, ok := entry["logging.googleapis.com/sourceLocation"].(map[string]any)
+	require.True(t, ok, "sourceLocation missing in %s", out.String())
+	assert.Contains(t, source["file"], "cloud_test.go")
+	assert.NotEmpty(t, source["line"])
+	assert.Contains(t, source["function"], "TestCloudHandler_AddsSourceLocation_When_AddSourceSet")
+}
+
+// TestParseTraceContext_SplitsTraceSpanAndSampling_When_HeaderHasAllParts (ADR-008 Naming)
+func TestParseTraceContext_SplitsTraceSpanAndSampling_When_HeaderHasAllParts(t *testing.T) {
+	// Act
+	trace, span, sampled := ParseTraceContext("105445aa7843bc8bf206b12000100000/255;o=1")
+
+	// Assert
+	assert.Equal(t, "105445aa7843bc8bf206b12000100000", trace)
+	assert.Equal(t, "00000000000000ff", span)
+	assert.True(t, sampled)
+}
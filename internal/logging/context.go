@@ -0,0 +1,75 @@
+// file: internal/logging/context.go
+package logging
+
+// context.go keeps the registry of correlation fields that Logger.WithContext reads
+// from a context, so every package logs them the same way.
+
+import (
+	"context"
+	"sync"
+)
+
+// Keys of the correlation fields registered by this module's packages.
+const (
+	// SpanIDKey is the span of the request within its trace.
+	SpanIDKey = "span_id"
+	// CallerKey identifies the agent or client that sent the request.
+	CallerKey = "caller"
+	// TenantKey identifies the tenant the request acts for.
+	TenantKey = "tenant"
+	// ToolKey is the name of the tool handling the request.
+	ToolKey = "tool"
+)
+
+// ContextExtractor returns the value of a correlation field carried by ctx, and
+// whether ctx carries one.
+type ContextExtractor func(ctx context.Context) (any, bool)
+
+type contextField struct {
+	key     string
+	extract ContextExtractor
+}
+
+var (
+	contextFieldsMu sync.RWMutex
+	contextFields   []contextField
+)
+
+// RegisterContextField makes WithContext add the field key, with the value extract
+// finds in the context, to every logger it enriches. Packages register the fields
+// they store in contexts from an init function. Registering a key again replaces
+// its extractor.
+func RegisterContextField(key string, extract ContextExtractor) {
+	contextFieldsMu.Lock()
+	defer contextFieldsMu.Unlock()
+	for i, f := range contextFields {
+		if f.key == key {
+			contextFields[i].extract = extract
+			return
+		}
+	}
+	contextFields = append(contextFields, contextField{key: key, extract: extract})
+}
+
+// StringFromContext returns an extractor for a non-empty string stored in a context
+// under key, the common case for RegisterContextField.
+func StringFromContext(key any) ContextExtractor {
+	return func(ctx context.Context) (any, bool) {
+		s, ok := ctx.Value(key).(string)
+		return s, ok && s != ""
+	}
+}
+
+// ContextFields returns the registered fields ctx carries as key-value pairs, in
+// registration order.
+func ContextFields(ctx context.Context) []any {
+	contextFieldsMu.RLock()
+	defer contextFieldsMu.RUnlock()
+	var fields []any
+	for _, f := range contextFields {
+		if value, ok := f.extract(ctx); ok {
+			fields = append(fields, f.key, value)
+		}
+	}
+	return fields
+}
@@ -0,0 +1,51 @@
+// file: internal/logging/context_test.go
+package logging
+
+import (
+	"bytes"
+	"context"
+	"log/slog"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type testContextKey struct{}
+
+// TestWithContext_AddsRegisteredFields_When_ContextCarriesThem (ADR-008 Naming)
+func TestWithContext_AddsRegisteredFields_When_ContextCarriesThem(t *testing.T) {
+	// Arrange
+	RegisterContextField("test_field", StringFromContext(testContextKey{}))
+	var out bytes.Buffer
+	logger := &SlogLogger{logger: slog.New(slog.NewTextHandler(&out, nil))}
+	withValue := context.WithValue(context.Background(), testContextKey{}, "v1")
+
+	// Act
+	logger.WithContext(withValue).Info("enriched")
+	logger.WithContext(context.Background()).Info("plain")
+
+	// Assert
+	lines := bytes.Split(bytes.TrimSpace(out.Bytes()), []byte("\n"))
+	assert.Len(t, lines, 2)
+	assert.Contains(t, string(lines[0]), "test_field=v1")
+	assert.NotContains(t, string(lines[1]), "test_field")
+}
+
+// TestRegisterContextField_ReplacesExtractor_When_KeyRegisteredTwice (ADR-008 Naming)
+func TestRegisterContextField_ReplacesExtractor_When_KeyRegisteredTwice(t *testing.T) {
+	// Arrange
+	RegisterContextField("replaced_field", func(context.Context) (any, bool) { return "old", true })
+
+	// Act
+	RegisterContextField("replaced_field", func(context.Context) (any, bool) { return "new", true })
+
+	// Assert
+	fields := ContextFields(context.Background())
+	var values []any
+	for i := 0; i+1 < len(fields); i += 2 {
+		if fields[i] == "replaced_field" {
+			values = append(values, fields[i+1])
+		}
+	}
+	assert.Equal(t, []any{"new"}, values)
+}
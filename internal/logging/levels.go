@@ -0,0 +1,350 @@
+// file: internal/logging/levels.go
+package logging
+
+// levels.go holds the minimum level of each component's loggers, which operators can
+// raise or lower at runtime without a restart.
+
+import (
+	"context"
+	"log/slog"
+	"os"
+	"os/signal"
+	"path"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/cockroachdb/errors"
+)
+
+// componentKey is the attribute GetLogger adds to name a logger's component.
+const componentKey = "component"
+
+// LevelRegistry holds the minimum level of every component's loggers, keyed by the
+// name passed to GetLogger. A component's level comes from the most specific rule
+// whose glob pattern (as in path.Match, e.g. "mcp*") matches its name, or the base
+// level if none does. Rules are configured at startup or set as overrides at
+// runtime; overrides take precedence over configured rules and may expire, reverting
+// to the configured level. Each component's loggers share a slog.LevelVar, so a
+// change applies to loggers that already exist. It is safe for concurrent use.
+type LevelRegistry struct {
+	mu         sync.Mutex
+	base       slog.Level
+	configured map[string]slog.Level
+	overrides  map[string]*levelOverride
+	// vars holds the level of each component seen so far; "" is loggers without one.
+	vars map[string]*slog.LevelVar
+}
+
+// levelOverride is a rule set at runtime; expires is zero when it does not expire.
+type levelOverride struct {
+	level   slog.Level
+	expires time.Time
+	timer   *time.Timer
+}
+
+// LevelOverride describes a runtime override, for the admin API.
+type LevelOverride struct {
+	Pattern   string     `json:"component"`
+	Level     string     `json:"level"`
+	ExpiresAt *time.Time `json:"expiresAt,omitempty"`
+}
+
+// NewLevelRegistry returns a registry with the base level and the configured rules,
+// keyed by component name pattern. It fails on a malformed pattern.
+func NewLevelRegistry(base slog.Level, configured map[string]slog.Level) (*LevelRegistry, error) {
+	for pattern := range configured {
+		if err := checkPattern(pattern); err != nil {
+			return nil, errors.Wrap(err, "NewLevelRegistry: invalid component pattern")
+		}
+	}
+	return &LevelRegistry{
+		base:       base,
+		configured: configured,
+		overrides:  make(map[string]*levelOverride),
+		vars:       make(map[string]*slog.LevelVar),
+	}, nil
+}
+
+// Reconfigure replaces the base level and the configured rules with those of opts,
+// e.g. after a configuration reload, and applies them to existing loggers. Overrides
+// are kept. On an invalid level or pattern it fails and leaves the registry unchanged.
+func (r *LevelRegistry) Reconfigure(opts Options) error {
+	base, configured, err := parseLevels(opts)
+	if err != nil {
+		return errors.Wrap(err, "LevelRegistry.Reconfigure: invalid levels")
+	}
+	for pattern := range configured {
+		if err := checkPattern(pattern); err != nil {
+			return errors.Wrap(err, "LevelRegistry.Reconfigure: invalid component pattern")
+		}
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.base, r.configured = base, configured
+	r.refresh()
+	return nil
+}
+
+// parseLevels parses the base level and the component levels of opts.
+func parseLevels(opts Options) (slog.Level, map[string]slog.Level, error) {
+	level, err := ParseLevel(opts.Level)
+	if err != nil {
+		return 0, nil, errors.Wrap(err, "parseLevels: invalid level")
+	}
+	components := make(map[string]slog.Level, len(opts.ComponentLevels))
+	for name, raw := range opts.ComponentLevels {
+		if components[name], err = ParseLevel(raw); err != nil {
+			return 0, nil, errors.Wrapf(err, "parseLevels: invalid level for component %s", name)
+		}
+	}
+	return level, components, nil
+}
+
+// checkPattern reports a malformed glob pattern.
+func checkPattern(pattern string) error {
+	if _, err := path.Match(pattern, ""); err != nil {
+		return errors.Wrapf(err, "checkPattern: %q", pattern)
+	}
+	return nil
+}
+
+// levelVar returns the shared level of a component's loggers.
+func (r *LevelRegistry) levelVar(component string) *slog.LevelVar {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	v, ok := r.vars[component]
+	if !ok {
+		v = new(slog.LevelVar)
+		v.Set(r.resolve(component))
+		r.vars[component] = v
+	}
+	return v
+}
+
+// resolve returns the level of component under the current rules. r.mu must be held.
+func (r *LevelRegistry) resolve(component string) slog.Level {
+	best, found := "", false
+	for pattern := range r.overrides {
+		if matches(pattern, component) && (!found || moreSpecific(pattern, best)) {
+			best, found = pattern, true
+		}
+	}
+	if found {
+		return r.overrides[best].level
+	}
+	for pattern := range r.configured {
+		if matches(pattern, component) && (!found || moreSpecific(pattern, best)) {
+			best, found = pattern, true
+		}
+	}
+	if found {
+		return r.configured[best]
+	}
+	return r.base
+}
+
+// matches reports whether component matches pattern; a malformed pattern never matches.
+func matches(pattern, component string) bool {
+	ok, _ := path.Match(pattern, component)
+	return ok
+}
+
+// moreSpecific orders patterns: an exact name before any glob, then more literal
+// characters first, then lexically so the choice is stable.
+func moreSpecific(a, b string) bool {
+	aExact, bExact := !strings.ContainsAny(a, `*?[\`), !strings.ContainsAny(b, `*?[\`)
+	if aExact != bExact {
+		return aExact
+	}
+	if la, lb := literalLen(a), literalLen(b); la != lb {
+		return la > lb
+	}
+	return a < b
+}
+
+// literalLen counts the characters of pattern other than the * and ? wildcards.
+func literalLen(pattern string) int {
+	return len(pattern) - strings.Count(pattern, "*") - strings.Count(pattern, "?")
+}
+
+// refresh recomputes the level of every component. r.mu must be held.
+func (r *LevelRegistry) refresh() {
+	for component, v := range r.vars {
+		v.Set(r.resolve(component))
+	}
+}
+
+// Set overrides the level of the components matching pattern. With a positive ttl
+// the override reverts after ttl; otherwise it lasts until Reset. Setting a pattern
+// again replaces its override and its expiry.
+func (r *LevelRegistry) Set(pattern string, level slog.Level, ttl time.Duration) (LevelOverride, error) {
+	if err := checkPattern(pattern); err != nil {
+		return LevelOverride{}, errors.Wrap(err, "LevelRegistry.Set: invalid component pattern")
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if old, ok := r.overrides[pattern]; ok && old.timer != nil {
+		old.timer.Stop()
+	}
+	o := &levelOverride{level: level}
+	if ttl > 0 {
+		o.expires = time.Now().Add(ttl)
+		o.timer = time.AfterFunc(ttl, func() { r.expire(pattern, o) })
+	}
+	r.overrides[pattern] = o
+	r.refresh()
+	return o.describe(pattern), nil
+}
+
+// expire removes the override o of pattern, unless it has since been replaced.
+func (r *LevelRegistry) expire(pattern string, o *levelOverride) {
+	r.mu.Lock()
+	if r.overrides[pattern] != o {
+		r.mu.Unlock()
+		return
+	}
+	delete(r.overrides, pattern)
+	r.refresh()
+	r.mu.Unlock()
+	GetLogger("logging").Info("Log level override expired.", "pattern", pattern, "level", o.level.String())
+}
+
+// Reset removes the override of pattern, reporting whether there was one.
+func (r *LevelRegistry) Reset(pattern string) bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	o, ok := r.overrides[pattern]
+	if !ok {
+		return false
+	}
+	if o.timer != nil {
+		o.timer.Stop()
+	}
+	delete(r.overrides, pattern)
+	r.refresh()
+	return true
+}
+
+// Overrides returns the active overrides, sorted by pattern.
+func (r *LevelRegistry) Overrides() []LevelOverride {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	overrides := make([]LevelOverride, 0, len(r.overrides))
+	for pattern, o := range r.overrides {
+		overrides = append(overrides, o.describe(pattern))
+	}
+	sort.Slice(overrides, func(i, j int) bool { return overrides[i].Pattern < overrides[j].Pattern })
+	return overrides
+}
+
+// Components returns the current level of every component that has a logger.
+func (r *LevelRegistry) Components() map[string]string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	levels := make(map[string]string, len(r.vars))
+	for component, v := range r.vars {
+		if component != "" {
+			levels[component] = LevelName(v.Level())
+		}
+	}
+	return levels
+}
+
+// ToggleDebug switches every component to debug for ttl or, if that toggle is
+// already on, switches it off. It reports whether debug is now on.
+func (r *LevelRegistry) ToggleDebug(ttl time.Duration) bool {
+	r.mu.Lock()
+	o, on := r.overrides["*"]
+	on = on && o.level == slog.LevelDebug
+	r.mu.Unlock()
+	if on {
+		r.Reset("*")
+		return false
+	}
+	_, _ = r.Set("*", slog.LevelDebug, ttl) // "*" is a valid pattern.
+	return true
+}
+
+// HandleSignal calls ToggleDebug each time sig arrives, until ctx is done. ttl is
+// called on each signal, so it can follow configuration reloads.
+func (r *LevelRegistry) HandleSignal(ctx context.Context, sig os.Signal, ttl func() time.Duration) {
+	ch := make(chan os.Signal, 1)
+	signal.Notify(ch, sig)
+	defer signal.Stop(ch)
+	r.toggleOn(ctx, ch, ttl)
+}
+
+// toggleOn calls ToggleDebug for each signal received on ch, until ctx is done.
+func (r *LevelRegistry) toggleOn(ctx context.Context, ch <-chan os.Signal, ttl func() time.Duration) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case sig := <-ch:
+			d := ttl()
+			on := r.ToggleDebug(d)
+			GetLogger("logging").Info("Debug logging toggled by signal.", "signal", sig.String(), "debug", on, "ttl", d.String())
+		}
+	}
+}
+
+// describe returns the override of pattern as reported by Overrides.
+func (o *levelOverride) describe(pattern string) LevelOverride {
+	d := LevelOverride{Pattern: pattern, Level: LevelName(o.level)}
+	if !o.expires.IsZero() {
+		expires := o.expires
+		d.ExpiresAt = &expires
+	}
+	return d
+}
+
+// LevelName returns the name ParseLevel accepts for level: debug, info, warn or error.
+func LevelName(level slog.Level) string {
+	return strings.ToLower(level.String())
+}
+
+// componentLevelHandler drops records below the level of the logger's component, as
+// held by a LevelRegistry: the component named by the component attribute added with
+// WithAttrs, or loggers without a component.
+type componentLevelHandler struct {
+	next     slog.Handler
+	registry *LevelRegistry
+	// level is the shared level of this handler's component.
+	level *slog.LevelVar
+}
+
+// newComponentLevelHandler wraps next at the level of loggers without a component.
+func newComponentLevelHandler(next slog.Handler, registry *LevelRegistry) *componentLevelHandler {
+	return &componentLevelHandler{next: next, registry: registry, level: registry.levelVar("")}
+}
+
+// Enabled reports whether level reaches the component's current level.
+func (h *componentLevelHandler) Enabled(_ context.Context, level slog.Level) bool {
+	return level >= h.level.Level()
+}
+
+// Handle passes the record on; Enabled has already filtered it.
+func (h *componentLevelHandler) Handle(ctx context.Context, r slog.Record) error {
+	return h.next.Handle(ctx, r)
+}
+
+// WithAttrs adds attrs, switching to the component's level when they name a component.
+func (h *componentLevelHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	clone := *h
+	clone.next = h.next.WithAttrs(attrs)
+	for _, a := range attrs {
+		if a.Key == componentKey {
+			clone.level = h.registry.levelVar(a.Value.String())
+		}
+	}
+	return &clone
+}
+
+// WithGroup opens a group in the wrapped handler.
+func (h *componentLevelHandler) WithGroup(name string) slog.Handler {
+	clone := *h
+	clone.next = h.next.WithGroup(name)
+	return &clone
+}
@@ -0,0 +1,199 @@
+// file: internal/logging/levels_test.go
+package logging
+
+import (
+	"bytes"
+	"context"
+	"log/slog"
+	"os"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newTestRegistryLogger returns a text logger writing to out whose levels r holds.
+func newTestRegistryLogger(r *LevelRegistry, out *bytes.Buffer) *SlogLogger {
+	return &SlogLogger{logger: slog.New(newComponentLevelHandler(slog.NewTextHandler(out, nil), r)), levels: r}
+}
+
+// TestLevelRegistry_UsesMostSpecificRule_When_PatternsOverlap (ADR-008 Naming)
+func TestLevelRegistry_UsesMostSpecificRule_When_PatternsOverlap(t *testing.T) {
+	// Arrange
+	r, err := NewLevelRegistry(slog.LevelInfo, map[string]slog.Level{
+		"*":        slog.LevelWarn,
+		"mcp*":     slog.LevelDebug,
+		"mcp_http": slog.LevelError,
+	})
+	require.NoError(t, err)
+
+	// Act
+	levels := map[string]slog.Level{}
+	for _, component := range []string{"", "config_load", "mcp_session", "mcp_http"} {
+		levels[component] = r.levelVar(component).Level()
+	}
+
+	// Assert
+	assert.Equal(t, map[string]slog.Level{
+		"":            slog.LevelWarn,
+		"config_load": slog.LevelWarn,
+		"mcp_session": slog.LevelDebug,
+		"mcp_http":    slog.LevelError,
+	}, levels)
+}
+
+// TestLevelRegistry_AppliesToExistingLoggers_When_OverrideSetAndReset (ADR-008 Naming)
+func TestLevelRegistry_AppliesToExistingLoggers_When_OverrideSetAndReset(t *testing.T) {
+	// Arrange
+	r, err := NewLevelRegistry(slog.LevelInfo, map[string]slog.Level{"mcp": slog.LevelWarn})
+	require.NoError(t, err)
+	var out bytes.Buffer
+	mcp := newTestRegistryLogger(r, &out).WithField("component", "mcp")
+
+	// Act
+	mcp.Info("before")
+	_, err = r.Set("mc?", slog.LevelDebug, 0)
+	require.NoError(t, err)
+	mcp.Debug("during")
+	reset := r.Reset("mc?")
+	mcp.Info("after")
+
+	// Assert
+	assert.True(t, reset)
+	assert.NotContains(t, out.String(), "msg=before")
+	assert.Contains(t, out.String(), "msg=during")
+	assert.NotContains(t, out.String(), "msg=after")
+	assert.Empty(t, r.Overrides())
+}
+
+// TestLevelRegistry_RevertsOverride_When_TTLElapses (ADR-008 Naming)
+func TestLevelRegistry_RevertsOverride_When_TTLElapses(t *testing.T) {
+	// Arrange
+	r, err := NewLevelRegistry(slog.LevelInfo, nil)
+	require.NoError(t, err)
+	level := r.levelVar("mcp")
+
+	// Act
+	override, err := r.Set("mcp", slog.LevelDebug, 20*time.Millisecond)
+	require.NoError(t, err)
+
+	// Assert
+	require.NotNil(t, override.ExpiresAt)
+	assert.Equal(t, LevelOverride{Pattern: "mcp", Level: "debug", ExpiresAt: override.ExpiresAt}, override)
+	assert.Equal(t, slog.LevelDebug, level.Level())
+	assert.Eventually(t, func() bool { return level.Level() == slog.LevelInfo }, time.Second, 5*time.Millisecond)
+	assert.Empty(t, r.Overrides())
+}
+
+// TestLevelRegistry_KeepsNewOverride_When_ReplacedBeforeTTL (ADR-008 Naming)
+func TestLevelRegistry_KeepsNewOverride_When_ReplacedBeforeTTL(t *testing.T) {
+	// Arrange
+	r, err := NewLevelRegistry(slog.LevelInfo, nil)
+	require.NoError(t, err)
+	_, err = r.Set("mcp", slog.LevelDebug, 10*time.Millisecond)
+	require.NoError(t, err)
+
+	// Act
+	_, err = r.Set("mcp", slog.LevelWarn, 0)
+	require.NoError(t, err)
+	time.Sleep(30 * time.Millisecond)
+
+	// Assert
+	assert.Equal(t, slog.LevelWarn, r.levelVar("mcp").Level())
+	assert.Equal(t, []LevelOverride{{Pattern: "mcp", Level: "warn"}}, r.Overrides())
+}
+
+// TestLevelRegistry_AppliesNewRules_When_Reconfigured (ADR-008 Naming)
+func TestLevelRegistry_AppliesNewRules_When_Reconfigured(t *testing.T) {
+	// Arrange
+	r, err := NewLevelRegistry(slog.LevelInfo, map[string]slog.Level{"mcp": slog.LevelWarn})
+	require.NoError(t, err)
+	var out bytes.Buffer
+	logger := newTestRegistryLogger(r, &out)
+	mcp, tools := logger.WithField("component", "mcp"), logger.WithField("component", "tools")
+	_, err = r.Set("tools", slog.LevelError, 0)
+	require.NoError(t, err)
+
+	// Act
+	err = r.Reconfigure(Options{Level: "warn", ComponentLevels: map[string]string{"mc*": "debug"}})
+	invalid := r.Reconfigure(Options{Level: "loud"})
+	logger.Info("base info")
+	mcp.Debug("mcp debug")
+	tools.Warn("tools warn")
+
+	// Assert
+	require.NoError(t, err)
+	assert.Error(t, invalid)
+	assert.NotContains(t, out.String(), "base info")
+	assert.Contains(t, out.String(), "mcp debug")
+	assert.NotContains(t, out.String(), "tools warn", "overrides survive a reconfiguration")
+}
+
+// TestLevelRegistry_ReturnsError_When_PatternMalformed (ADR-008 Naming)
+func TestLevelRegistry_ReturnsError_When_PatternMalformed(t *testing.T) {
+	// Arrange
+	r, err := NewLevelRegistry(slog.LevelInfo, nil)
+	require.NoError(t, err)
+
+	// Act
+	_, setErr := r.Set("mcp[", slog.LevelDebug, time.Minute)
+	_, newErr := NewLevelRegistry(slog.LevelInfo, map[string]slog.Level{"[": slog.LevelDebug})
+
+	// Assert
+	assert.Error(t, setErr)
+	assert.Error(t, newErr)
+}
+
+// TestHandleSignal_TogglesDebug_When_SignalReceived (ADR-008 Naming)
+func TestHandleSignal_TogglesDebug_When_SignalReceived(t *testing.T) {
+	// Arrange
+	r, err := NewLevelRegistry(slog.LevelWarn, nil)
+	require.NoError(t, err)
+	level := r.levelVar("mcp")
+	// signalOnce delivers one signal and returns once it has been handled.
+	signalOnce := func() {
+		ctx, cancel := context.WithCancel(context.Background())
+		ch := make(chan os.Signal)
+		done := make(chan struct{})
+		go func() {
+			r.toggleOn(ctx, ch, func() time.Duration { return time.Minute })
+			close(done)
+		}()
+		ch <- syscall.SIGUSR1
+		cancel()
+		<-done
+	}
+
+	// Act
+	signalOnce()
+	afterFirst := level.Level()
+	signalOnce()
+	afterSecond := level.Level()
+
+	// Assert
+	assert.Equal(t, slog.LevelDebug, afterFirst, "first signal turns debug on")
+	assert.Equal(t, slog.LevelWarn, afterSecond, "second signal turns debug off")
+}
+
+// TestComponents_ReportsCurrentLevels_When_LoggersCreated (ADR-008 Naming)
+func TestComponents_ReportsCurrentLevels_When_LoggersCreated(t *testing.T) {
+	// Arrange
+	r, err := NewLevelRegistry(slog.LevelInfo, map[string]slog.Level{"mcp": slog.LevelDebug})
+	require.NoError(t, err)
+	var out bytes.Buffer
+	logger := newTestRegistryLogger(r, &out)
+	logger.WithField("component", "mcp")
+	logger.WithField("component", "config_load")
+
+	// Act
+	on := r.ToggleDebug(time.Minute)
+	components := r.Components()
+
+	// Assert
+	assert.True(t, on)
+	assert.Equal(t, map[string]string{"mcp": "debug", "config_load": "debug"}, components)
+	assert.False(t, r.ToggleDebug(time.Minute))
+	assert.Equal(t, "info", r.Components()["config_load"])
+}
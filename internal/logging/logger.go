@@ -29,8 +29,8 @@ type Logger interface {
 	// Arguments are handled as key-value pairs. It's recommended to include an 'error' key with the actual error object.
 	Error(msg string, args ...any)
 
-	// WithContext returns a logger instance potentially enriched with values from the provided context.
-	// The exact behavior depends on the underlying logger implementation.
+	// WithContext returns a logger instance enriched with the correlation fields the context
+	// carries, as registered with RegisterContextField (trace ID, caller, tool name, ...).
 	WithContext(ctx context.Context) Logger
 
 	// WithField returns a new logger instance with the specified key-value pair added to its context.
@@ -0,0 +1,215 @@
+// file: internal/logging/logtest/logger.go
+package logtest
+
+// logger.go provides Logger, an in-memory logging.Logger that captures records so
+// tests can assert on what code under test logged.
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/dkoosis/hello-tool-base/internal/logging"
+)
+
+// Entry is one captured record.
+type Entry struct {
+	Level   slog.Level
+	Message string
+	// Attrs holds the fields added with WithField and WithContext, then the record's
+	// own key-value pairs, in order. A later attribute with the same key wins in Value.
+	Attrs []slog.Attr
+}
+
+// Value returns the value of the last attribute named key, resolved to a plain Go
+// value (int64 for integers, []slog.Attr for groups), and whether there is one.
+func (e Entry) Value(key string) (any, bool) {
+	for i := len(e.Attrs) - 1; i >= 0; i-- {
+		if e.Attrs[i].Key == key {
+			return e.Attrs[i].Value.Resolve().Any(), true
+		}
+	}
+	return nil, false
+}
+
+// TraceID returns the trace ID part of the entry's logging.TraceIDKey attribute.
+func (e Entry) TraceID() string {
+	v, _ := e.Value(logging.TraceIDKey)
+	s, _ := v.(string)
+	trace, _, _ := logging.ParseTraceContext(s)
+	return trace
+}
+
+// String renders the entry on one line, for failure messages.
+func (e Entry) String() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "%s %q", e.Level, e.Message)
+	for _, a := range e.Attrs {
+		fmt.Fprintf(&b, " %s=%v", a.Key, a.Value.Resolve())
+	}
+	return b.String()
+}
+
+// sink holds the entries of a Logger and of every logger derived from it.
+type sink struct {
+	mu      sync.Mutex
+	entries []Entry
+}
+
+// Logger is a logging.Logger that keeps every record in memory. Loggers returned by
+// WithField and WithContext add their fields to the records and share the parent's
+// entries. It is safe for concurrent use.
+type Logger struct {
+	sink   *sink
+	fields []slog.Attr
+}
+
+var _ logging.Logger = (*Logger)(nil)
+
+// New returns a Logger with no entries.
+func New() *Logger {
+	return &Logger{sink: &sink{}}
+}
+
+// Debug records a debug-level message.
+func (l *Logger) Debug(msg string, args ...any) { l.log(slog.LevelDebug, msg, args) }
+
+// Info records an info-level message.
+func (l *Logger) Info(msg string, args ...any) { l.log(slog.LevelInfo, msg, args) }
+
+// Warn records a warning-level message.
+func (l *Logger) Warn(msg string, args ...any) { l.log(slog.LevelWarn, msg, args) }
+
+// Error records an error-level message.
+func (l *Logger) Error(msg string, args ...any) { l.log(slog.LevelError, msg, args) }
+
+// WithField returns a logger adding key and value to every record.
+func (l *Logger) WithField(key string, value any) logging.Logger {
+	return l.with(slog.Any(key, value))
+}
+
+// WithContext returns a logger adding the correlation fields ctx carries, as
+// logging.ContextFields returns them. It returns l itself when ctx carries none.
+func (l *Logger) WithContext(ctx context.Context) logging.Logger {
+	fields := logging.ContextFields(ctx)
+	if len(fields) == 0 {
+		return l
+	}
+	return l.with(attrs(fields)...)
+}
+
+func (l *Logger) with(fields ...slog.Attr) *Logger {
+	return &Logger{sink: l.sink, fields: append(l.fields[:len(l.fields):len(l.fields)], fields...)}
+}
+
+func (l *Logger) log(level slog.Level, msg string, args []any) {
+	entry := Entry{Level: level, Message: msg, Attrs: append(l.fields[:len(l.fields):len(l.fields)], attrs(args)...)}
+	l.sink.mu.Lock()
+	defer l.sink.mu.Unlock()
+	l.sink.entries = append(l.sink.entries, entry)
+}
+
+// attrs converts key-value pairs and slog.Attrs as slog does, so a key without a
+// value is recorded under "!BADKEY".
+func attrs(args []any) []slog.Attr {
+	rec := slog.NewRecord(time.Time{}, 0, "", 0)
+	rec.Add(args...)
+	out := make([]slog.Attr, 0, rec.NumAttrs())
+	rec.Attrs(func(a slog.Attr) bool {
+		out = append(out, a)
+		return true
+	})
+	return out
+}
+
+// Entries returns the captured entries in the order they were logged.
+func (l *Logger) Entries() []Entry {
+	l.sink.mu.Lock()
+	defer l.sink.mu.Unlock()
+	return append([]Entry(nil), l.sink.entries...)
+}
+
+// ForTrace returns the entries logged for the trace traceID, given either as the
+// trace ID or as a whole X-Cloud-Trace-Context value.
+func (l *Logger) ForTrace(traceID string) []Entry {
+	traceID, _, _ = logging.ParseTraceContext(traceID)
+	var entries []Entry
+	for _, e := range l.Entries() {
+		if e.TraceID() == traceID {
+			entries = append(entries, e)
+		}
+	}
+	return entries
+}
+
+// Reset discards the captured entries.
+func (l *Logger) Reset() {
+	l.sink.mu.Lock()
+	defer l.sink.mu.Unlock()
+	l.sink.entries = nil
+}
+
+// Find returns the entries at level with message msg that have all the attributes
+// want, given as key-value pairs or slog.Attrs. Values are compared after the same
+// conversion slog applies, so an int matches the int64 a logger recorded.
+func (l *Logger) Find(level slog.Level, msg string, want ...any) []Entry {
+	wantAttrs := attrs(want)
+	var found []Entry
+	for _, e := range l.Entries() {
+		if e.Level == level && e.Message == msg && hasAttrs(e, wantAttrs) {
+			found = append(found, e)
+		}
+	}
+	return found
+}
+
+func hasAttrs(e Entry, want []slog.Attr) bool {
+	for _, w := range want {
+		got, ok := e.Value(w.Key)
+		if !ok || !reflect.DeepEqual(got, w.Value.Resolve().Any()) {
+			return false
+		}
+	}
+	return true
+}
+
+// AssertLogged reports a test error listing the captured entries unless an entry at
+// level with message msg has all the attributes want. It returns true when one does.
+func (l *Logger) AssertLogged(t testing.TB, level slog.Level, msg string, want ...any) bool {
+	t.Helper()
+	if len(l.Find(level, msg, want...)) > 0 {
+		return true
+	}
+	t.Errorf("logtest: no %s entry %q with attributes %v among:\n%s", level, msg, want, l.dump())
+	return false
+}
+
+// AssertNotLogged reports a test error if an entry at level with message msg has
+// all the attributes want. It returns true when none does.
+func (l *Logger) AssertNotLogged(t testing.TB, level slog.Level, msg string, want ...any) bool {
+	t.Helper()
+	found := l.Find(level, msg, want...)
+	if len(found) == 0 {
+		return true
+	}
+	t.Errorf("logtest: unexpected %s entry %q: %s", level, msg, found[0])
+	return false
+}
+
+// dump renders the captured entries, one per line.
+func (l *Logger) dump() string {
+	entries := l.Entries()
+	if len(entries) == 0 {
+		return "  (none)"
+	}
+	lines := make([]string, len(entries))
+	for i, e := range entries {
+		lines[i] = "  " + e.String()
+	}
+	return strings.Join(lines, "\n")
+}
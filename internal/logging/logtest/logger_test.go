@@ -0,0 +1,79 @@
+// file: internal/logging/logtest/logger_test.go
+package logtest
+
+import (
+	"context"
+	"log/slog"
+	"testing"
+
+	"github.com/dkoosis/hello-tool-base/internal/logging"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type traceKey struct{}
+
+func init() {
+	logging.RegisterContextField("logtest_trace", logging.StringFromContext(traceKey{}))
+}
+
+// TestLogger_RecordsLevelMessageAndFields_When_DerivedLoggersLog (ADR-008 Naming)
+func TestLogger_RecordsLevelMessageAndFields_When_DerivedLoggersLog(t *testing.T) {
+	// Arrange
+	log := New()
+	ctx := context.WithValue(context.Background(), traceKey{}, "t-1")
+	derived := log.WithField("component", "test").WithContext(ctx)
+
+	// Act
+	log.Debug("root")
+	derived.Warn("derived", "status", 400, slog.Bool("retry", false))
+
+	// Assert
+	entries := log.Entries()
+	require.Len(t, entries, 2)
+	assert.Equal(t, Entry{Level: slog.LevelDebug, Message: "root"}, entries[0])
+	assert.Equal(t, slog.LevelWarn, entries[1].Level)
+	assert.Equal(t, []slog.Attr{
+		slog.Any("component", "test"), slog.String("logtest_trace", "t-1"),
+		slog.Int("status", 400), slog.Bool("retry", false),
+	}, entries[1].Attrs)
+	log.AssertLogged(t, slog.LevelWarn, "derived", "component", "test", "status", 400)
+	log.AssertNotLogged(t, slog.LevelWarn, "derived", "status", 500)
+}
+
+// TestForTrace_ReturnsTheTracesEntries_When_RequestsInterleave (ADR-008 Naming)
+func TestForTrace_ReturnsTheTracesEntries_When_RequestsInterleave(t *testing.T) {
+	// Arrange
+	log := New()
+	log.WithField(logging.TraceIDKey, "aaa/1;o=1").Info("first a")
+	log.WithField(logging.TraceIDKey, "bbb").Info("first b")
+	log.WithField(logging.TraceIDKey, "aaa/2;o=1").Info("second a")
+	log.Info("no trace")
+
+	// Act
+	entries := log.ForTrace("aaa")
+
+	// Assert
+	require.Len(t, entries, 2)
+	assert.Equal(t, "first a", entries[0].Message)
+	assert.Equal(t, "second a", entries[1].Message)
+	assert.Len(t, log.ForTrace("bbb/9"), 1)
+}
+
+// TestAssertLogged_ReportsEntries_When_NoEntryMatches (ADR-008 Naming)
+func TestAssertLogged_ReportsEntries_When_NoEntryMatches(t *testing.T) {
+	// Arrange
+	log := New()
+	log.Error("Request failed.", "status", 500)
+	inner := &recordingTB{TB: t}
+
+	// Act
+	ok := log.AssertLogged(inner, slog.LevelError, "Request failed.", "status", 400)
+	log.Reset()
+
+	// Assert
+	assert.False(t, ok)
+	require.Len(t, inner.errors, 1)
+	assert.Contains(t, inner.errors[0], `ERROR "Request failed." status=500`)
+	assert.Empty(t, log.Entries())
+}
@@ -0,0 +1,59 @@
+// Package logtest provides helpers for asserting, in tests, on what the service logs.
+// file: internal/logging/logtest/redact.go
+package logtest
+
+import (
+	"bytes"
+	"context"
+	"log/slog"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/dkoosis/hello-tool-base/internal/logging"
+)
+
+// NewRecord returns an info record with msg and args, given as key-value pairs or
+// slog.Attrs as to Logger.Info, for AssertRedacted.
+func NewRecord(msg string, args ...any) slog.Record {
+	rec := slog.NewRecord(time.Time{}, slog.LevelInfo, msg, 0)
+	rec.Add(args...)
+	return rec
+}
+
+// Render returns rec as the JSON line a logger redacting with r would write.
+func Render(r *logging.Redactor, rec slog.Record) string {
+	var out bytes.Buffer
+	_ = logging.NewRedactHandler(slog.NewJSONHandler(&out, nil), r).Handle(context.Background(), rec)
+	return strings.TrimSpace(out.String())
+}
+
+// AssertRedacted reports a test error for each of secrets still present in rec once
+// r has redacted it. It returns true when none is.
+func AssertRedacted(t testing.TB, r *logging.Redactor, rec slog.Record, secrets ...string) bool {
+	t.Helper()
+	line := Render(r, rec)
+	ok := true
+	for _, secret := range secrets {
+		if strings.Contains(line, secret) {
+			t.Errorf("logtest: %q is not redacted in %s", secret, line)
+			ok = false
+		}
+	}
+	return ok
+}
+
+// AssertNotRedacted reports a test error for each of values masked from rec by r, to
+// check that rules do not hide what operators need. It returns true when none is.
+func AssertNotRedacted(t testing.TB, r *logging.Redactor, rec slog.Record, values ...string) bool {
+	t.Helper()
+	line := Render(r, rec)
+	ok := true
+	for _, value := range values {
+		if !strings.Contains(line, value) {
+			t.Errorf("logtest: %q is redacted in %s", value, line)
+			ok = false
+		}
+	}
+	return ok
+}
@@ -0,0 +1,77 @@
+// file: internal/logging/logtest/redact_test.go
+package logtest
+
+import (
+	"fmt"
+	"log/slog"
+	"testing"
+
+	"github.com/dkoosis/hello-tool-base/internal/logging"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestAssertRedacted_Passes_When_DefaultRulesMaskTheSecrets (ADR-008 Naming)
+func TestAssertRedacted_Passes_When_DefaultRulesMaskTheSecrets(t *testing.T) {
+	// Arrange
+	r, err := logging.NewRedactor(logging.DefaultRedactKeys, logging.DefaultRedactPatterns)
+	require.NoError(t, err)
+	rec := NewRecord("Charge failed.",
+		"authorization", "Bearer abc",
+		"customer", slog.GroupValue(slog.String("email", "ada@example.com"), slog.String("card", "5555555555554444")),
+		"trace_id", "trace-1")
+
+	// Act
+	redacted := AssertRedacted(t, r, rec, "abc", "ada@example.com", "5555555555554444")
+	kept := AssertNotRedacted(t, r, rec, "Charge failed.", "trace-1")
+
+	// Assert
+	assert.True(t, redacted)
+	assert.True(t, kept)
+}
+
+// TestAssertNotRedacted_Passes_When_CorrelationIDsPassTheLuhnCheck (ADR-008 Naming)
+func TestAssertNotRedacted_Passes_When_CorrelationIDsPassTheLuhnCheck(t *testing.T) {
+	// Arrange
+	r, err := logging.NewRedactor(logging.DefaultRedactKeys, logging.DefaultRedactPatterns)
+	require.NoError(t, err)
+	traceContext := "105445aa7843bc8bf206b12000100000/4111111111111111;o=1"
+	rec := NewRecord("Request handled.",
+		logging.TraceIDKey, traceContext,
+		logging.SpanIDKey, "4111111111111111",
+		"component", "4111-1111-1111-1111")
+
+	// Act
+	kept := AssertNotRedacted(t, r, rec, traceContext, `"span_id":"4111111111111111"`, "4111-1111-1111-1111")
+
+	// Assert
+	assert.True(t, kept)
+}
+
+// recordingTB records the errors reported by an assertion under test.
+type recordingTB struct {
+	testing.TB
+	errors []string
+}
+
+func (r *recordingTB) Helper() {}
+
+func (r *recordingTB) Errorf(format string, args ...any) {
+	r.errors = append(r.errors, fmt.Sprintf(format, args...))
+}
+
+// TestAssertRedacted_ReportsError_When_SecretSurvives (ADR-008 Naming)
+func TestAssertRedacted_ReportsError_When_SecretSurvives(t *testing.T) {
+	// Arrange
+	r, err := logging.NewRedactor(nil, nil)
+	require.NoError(t, err)
+	inner := &recordingTB{TB: t}
+
+	// Act
+	ok := AssertRedacted(inner, r, NewRecord("Login.", "password", "hunter2"), "hunter2")
+
+	// Assert
+	assert.False(t, ok)
+	require.Len(t, inner.errors, 1)
+	assert.Contains(t, inner.errors[0], `"hunter2" is not redacted`)
+}
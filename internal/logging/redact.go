@@ -0,0 +1,314 @@
+// file: internal/logging/redact.go
+package logging
+
+// redact.go masks sensitive values in log records before any handler formats them:
+// the values of sensitive keys, and sensitive substrings such as bearer tokens, card
+// numbers and email addresses wherever they appear, including error strings and URLs.
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"reflect"
+	"regexp"
+	"slices"
+	"strings"
+	"unicode"
+
+	"github.com/cockroachdb/errors"
+)
+
+// Redacted replaces a masked value.
+const Redacted = "[REDACTED]"
+
+// Built-in value patterns accepted by NewRedactor; any other pattern is a regular
+// expression.
+const (
+	PatternBearer = "bearer"
+	PatternCard   = "card"
+	PatternEmail  = "email"
+)
+
+// DefaultRedactKeys are the sensitive keys masked by default.
+var DefaultRedactKeys = []string{"authorization", "api_key", "password", "token", "cookie"}
+
+// DefaultRedactPatterns are the value patterns masked by default.
+var DefaultRedactPatterns = []string{PatternBearer, PatternCard, PatternEmail}
+
+// valuePattern is a pattern whose matches are masked. valid, when set, filters the
+// matches, so a pattern can be loose while only real secrets are masked.
+type valuePattern struct {
+	re    *regexp.Regexp
+	valid func(match string) bool
+}
+
+// builtinPatterns are the patterns named by the Pattern constants.
+var builtinPatterns = map[string]valuePattern{
+	PatternBearer: {re: regexp.MustCompile(`(?i)\bbearer\s+[A-Za-z0-9\-._~+/]+=*`)},
+	// 13 to 16 digits, optionally grouped by spaces or dashes; the Luhn check keeps
+	// most other numbers, such as IDs and timestamps, readable.
+	PatternCard:  {re: regexp.MustCompile(`\b\d(?:[ -]?\d){12,15}\b`), valid: luhn},
+	PatternEmail: {re: regexp.MustCompile(`[A-Za-z0-9._%+\-]+@[A-Za-z0-9.\-]+\.[A-Za-z]{2,}`)},
+}
+
+// correlationKeys are the keys of this package's correlation fields. Their values are
+// identifiers, such as span IDs that happen to pass the Luhn check, so value patterns
+// are not applied to them.
+var correlationKeys = []string{TraceIDKey, SpanIDKey, componentKey}
+
+// Redactor masks sensitive attributes and values. A key is sensitive when, written in
+// snake case, it is one of the configured keys or ends with "_" and one of them: with
+// the key "token", "token", "accessToken" and "X-Auth-Token" are masked but
+// "max_tokens" is not. The same keys are masked in "key=value" pairs inside strings,
+// such as URL query parameters. It is safe for concurrent use.
+type Redactor struct {
+	keys     []string
+	pairs    *regexp.Regexp
+	patterns []valuePattern
+}
+
+// NewRedactor returns a Redactor masking the given keys and value patterns. Each
+// pattern is PatternBearer, PatternCard, PatternEmail or a regular expression. It
+// fails on a malformed regular expression.
+func NewRedactor(keys, patterns []string) (*Redactor, error) {
+	r := &Redactor{}
+	quoted := make([]string, 0, len(keys))
+	for _, key := range keys {
+		if key = snakeCase(key); key != "" {
+			r.keys = append(r.keys, key)
+			quoted = append(quoted, regexp.QuoteMeta(key))
+		}
+	}
+	if len(quoted) > 0 {
+		r.pairs = regexp.MustCompile(`(?i)\b((?:[\w-]*[_-])?(?:` + strings.Join(quoted, "|") + `)=)[^&\s;,"']+`)
+	}
+	for _, p := range patterns {
+		if builtin, ok := builtinPatterns[p]; ok {
+			r.patterns = append(r.patterns, builtin)
+			continue
+		}
+		re, err := regexp.Compile(p)
+		if err != nil {
+			return nil, errors.Wrapf(err, "NewRedactor: invalid pattern %q", p)
+		}
+		r.patterns = append(r.patterns, valuePattern{re: re})
+	}
+	return r, nil
+}
+
+// defaultRedactor applies the default rules; they are known to be valid.
+func defaultRedactor() *Redactor {
+	r, _ := NewRedactor(DefaultRedactKeys, DefaultRedactPatterns)
+	return r
+}
+
+// sensitiveKey reports whether attribute key is one of the configured keys.
+func (r *Redactor) sensitiveKey(key string) bool {
+	key = snakeCase(key)
+	return slices.ContainsFunc(r.keys, func(k string) bool {
+		return key == k || strings.HasSuffix(key, "_"+k)
+	})
+}
+
+// String returns s with every sensitive substring masked.
+func (r *Redactor) String(s string) string {
+	if r.pairs != nil {
+		s = r.pairs.ReplaceAllString(s, "${1}"+Redacted)
+	}
+	for _, p := range r.patterns {
+		s = p.re.ReplaceAllStringFunc(s, func(match string) string {
+			if p.valid != nil && !p.valid(match) {
+				return match
+			}
+			return Redacted
+		})
+	}
+	return s
+}
+
+// Attr returns a with its value masked if its key is sensitive, or with the sensitive
+// parts of its value masked otherwise. Groups are redacted recursively and
+// slog.LogValuers are resolved first. Other values are redacted by anyValue. The
+// values of correlation fields (trace, span and component) are kept as they are.
+func (r *Redactor) Attr(a slog.Attr) slog.Attr {
+	if r.sensitiveKey(a.Key) {
+		return slog.String(a.Key, Redacted)
+	}
+	a.Value = a.Value.Resolve()
+	if slices.Contains(correlationKeys, a.Key) {
+		return a
+	}
+	switch a.Value.Kind() {
+	case slog.KindString:
+		a.Value = slog.StringValue(r.String(a.Value.String()))
+	case slog.KindGroup:
+		group := a.Value.Group()
+		redacted := make([]slog.Attr, len(group))
+		for i, ga := range group {
+			redacted[i] = r.Attr(ga)
+		}
+		a.Value = slog.GroupValue(redacted...)
+	case slog.KindAny:
+		a.Value = r.anyValue(a.Value)
+	}
+	return a
+}
+
+// anyValue redacts a value of kind Any. Maps, structs and slices are redacted in their
+// JSON form, so sensitive keys are masked at any depth; errors, fmt.Stringers and
+// values that cannot be encoded are redacted in their string form. The value is
+// replaced, by the redacted JSON value or string, only if something was masked.
+func (r *Redactor) anyValue(v slog.Value) slog.Value {
+	x := v.Any()
+	switch x.(type) {
+	case error, fmt.Stringer:
+	default:
+		switch reflect.Indirect(reflect.ValueOf(x)).Kind() {
+		case reflect.Map, reflect.Struct, reflect.Slice, reflect.Array:
+			if decoded, ok := decodeJSON(x); ok {
+				if redacted, masked := r.redactJSON(decoded); masked {
+					return slog.AnyValue(redacted)
+				}
+				return v
+			}
+		}
+	}
+	s := fmt.Sprint(x)
+	if masked := r.String(s); masked != s {
+		return slog.StringValue(masked)
+	}
+	return v
+}
+
+// decodeJSON round-trips x through JSON into maps, slices and scalars, keeping
+// numbers as json.Number so they are logged as written.
+func decodeJSON(x any) (any, bool) {
+	data, err := json.Marshal(x)
+	if err != nil {
+		return nil, false
+	}
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	var decoded any
+	if err := dec.Decode(&decoded); err != nil {
+		return nil, false
+	}
+	return decoded, true
+}
+
+// redactJSON masks the values of sensitive keys and the sensitive substrings of
+// strings in a decoded JSON value, in place, and reports whether it masked anything.
+func (r *Redactor) redactJSON(v any) (any, bool) {
+	masked := false
+	switch v := v.(type) {
+	case string:
+		s := r.String(v)
+		return s, s != v
+	case map[string]any:
+		for key, elem := range v {
+			if r.sensitiveKey(key) {
+				v[key], masked = Redacted, true
+				continue
+			}
+			var m bool
+			v[key], m = r.redactJSON(elem)
+			masked = masked || m
+		}
+	case []any:
+		for i, elem := range v {
+			var m bool
+			v[i], m = r.redactJSON(elem)
+			masked = masked || m
+		}
+	}
+	return v, masked
+}
+
+// Record returns a copy of rec with its message and attributes redacted.
+func (r *Redactor) Record(rec slog.Record) slog.Record {
+	out := slog.NewRecord(rec.Time, rec.Level, r.String(rec.Message), rec.PC)
+	rec.Attrs(func(a slog.Attr) bool {
+		out.AddAttrs(r.Attr(a))
+		return true
+	})
+	return out
+}
+
+// redactHandler redacts records and attributes before passing them to next.
+type redactHandler struct {
+	next     slog.Handler
+	redactor *Redactor
+}
+
+// NewRedactHandler returns a handler that redacts every record and attribute with r
+// before next sees it.
+func NewRedactHandler(next slog.Handler, r *Redactor) slog.Handler {
+	return &redactHandler{next: next, redactor: r}
+}
+
+// Enabled reports whether next handles level.
+func (h *redactHandler) Enabled(ctx context.Context, level slog.Level) bool {
+	return h.next.Enabled(ctx, level)
+}
+
+// Handle passes the redacted record to next.
+func (h *redactHandler) Handle(ctx context.Context, rec slog.Record) error {
+	return h.next.Handle(ctx, h.redactor.Record(rec))
+}
+
+// WithAttrs adds the redacted attrs to next.
+func (h *redactHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	redacted := make([]slog.Attr, len(attrs))
+	for i, a := range attrs {
+		redacted[i] = h.redactor.Attr(a)
+	}
+	return &redactHandler{next: h.next.WithAttrs(redacted), redactor: h.redactor}
+}
+
+// WithGroup opens a group in next.
+func (h *redactHandler) WithGroup(name string) slog.Handler {
+	return &redactHandler{next: h.next.WithGroup(name), redactor: h.redactor}
+}
+
+// snakeCase lowercases key, splitting camel case and replacing '-', '.' and spaces
+// with '_': "X-Api-Key" and "apiKey" become "x_api_key" and "api_key".
+func snakeCase(key string) string {
+	var b strings.Builder
+	var prev rune
+	for _, c := range key {
+		original := c
+		switch {
+		case c == '-' || c == '.' || c == ' ':
+			c = '_'
+		case unicode.IsUpper(c):
+			if unicode.IsLower(prev) || unicode.IsDigit(prev) {
+				b.WriteByte('_')
+			}
+			c = unicode.ToLower(c)
+		}
+		b.WriteRune(c)
+		prev = original
+	}
+	return b.String()
+}
+
+// luhn reports whether the digits of s pass the Luhn checksum of payment card numbers.
+func luhn(s string) bool {
+	sum, double := 0, false
+	for i := len(s) - 1; i >= 0; i-- {
+		if s[i] < '0' || s[i] > '9' {
+			continue
+		}
+		d := int(s[i] - '0')
+		if double {
+			if d *= 2; d > 9 {
+				d -= 9
+			}
+		}
+		sum += d
+		double = !double
+	}
+	return sum%10 == 0
+}
@@ -0,0 +1,154 @@
+// file: internal/logging/redact_test.go
+package logging
+
+import (
+	"bytes"
+	"errors"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestRedactor_MasksSensitiveKeys_When_KeyMatchesInAnyCase (ADR-008 Naming)
+func TestRedactor_MasksSensitiveKeys_When_KeyMatchesInAnyCase(t *testing.T) {
+	// Arrange
+	r, err := NewRedactor(DefaultRedactKeys, nil)
+	require.NoError(t, err)
+
+	tests := []struct {
+		key       string
+		sensitive bool
+	}{
+		{"Authorization", true},
+		{"X-Api-Key", true},
+		{"apiKey", true},
+		{"password", true},
+		{"accessToken", true},
+		{"refresh_token", true},
+		{"Set-Cookie", true},
+		{"max_tokens", false},
+		{"trace_id", false},
+		{"tokenizer", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.key, func(t *testing.T) {
+			// Act
+			got := r.Attr(slog.String(tt.key, "value"))
+
+			// Assert
+			assert.Equal(t, tt.key, got.Key)
+			assert.Equal(t, tt.sensitive, got.Value.String() == Redacted)
+		})
+	}
+}
+
+// TestRedactor_MasksSensitiveSubstrings_When_PatternsMatch (ADR-008 Naming)
+func TestRedactor_MasksSensitiveSubstrings_When_PatternsMatch(t *testing.T) {
+	// Arrange
+	r, err := NewRedactor(DefaultRedactKeys, append(DefaultRedactPatterns, `sk_live_\w+`))
+	require.NoError(t, err)
+
+	tests := []struct {
+		name, in, want string
+	}{
+		{"bearer token", "header Authorization: Bearer abc.def-ghi=", "header Authorization: [REDACTED]"},
+		{"card number", "card 4111 1111 1111 1111 declined", "card [REDACTED] declined"},
+		{"not a card number", "order 1234567890123 placed", "order 1234567890123 placed"},
+		{"email", "sent to ada@example.com.", "sent to [REDACTED]."},
+		{"query parameter", "/hello?name=Ada&api_key=k-123&x=1", "/hello?name=Ada&api_key=[REDACTED]&x=1"},
+		{"custom pattern", "charged sk_live_4eC39H", "charged [REDACTED]"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Act
+			got := r.String(tt.in)
+
+			// Assert
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+// TestNewLogger_RedactsRecords_When_AttributesAreNestedOrErrors (ADR-008 Naming)
+func TestNewLogger_RedactsRecords_When_AttributesAreNestedOrErrors(t *testing.T) {
+	// Arrange
+	path := filepath.Join(t.TempDir(), "app.log")
+	logger, err := NewLogger(Options{Format: FormatCloud, Output: path})
+	require.NoError(t, err)
+
+	// Act
+	logger.WithField("token", "t-secret").Info("Login for ada@example.com",
+		"request", slog.GroupValue(slog.String("cookie", "c-secret"), slog.String("path", "/login")),
+		HTTPRequestKey, HTTPRequest{Method: "GET", URL: "/cb?token=q-secret"},
+		"error", errors.New("upstream rejected Bearer b-secret"),
+		TraceIDKey, "105445aa7843bc8bf206b12000100000/1;o=1")
+
+	// Assert
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	for _, secret := range []string{"t-secret", "ada@example.com", "c-secret", "q-secret", "b-secret"} {
+		assert.NotContains(t, string(data), secret)
+	}
+	assert.Contains(t, string(data), `"path":"/login"`)
+	assert.Contains(t, string(data), `"logging.googleapis.com/trace":"105445aa7843bc8bf206b12000100000"`)
+}
+
+// TestRedactor_MasksSensitiveKeys_When_ValueIsMapOrStruct (ADR-008 Naming)
+func TestRedactor_MasksSensitiveKeys_When_ValueIsMapOrStruct(t *testing.T) {
+	// Arrange
+	r, err := NewRedactor(DefaultRedactKeys, DefaultRedactPatterns)
+	require.NoError(t, err)
+	type credentials struct {
+		User     string `json:"user"`
+		Password string
+		Retries  int `json:"retries"`
+	}
+	var out bytes.Buffer
+	logger := slog.New(NewRedactHandler(slog.NewJSONHandler(&out, nil), r))
+
+	// Act
+	logger.Info("Calling upstream.",
+		"headers", map[string]any{"Authorization": "Basic m-secret", "Accept": "application/json"},
+		"login", &credentials{User: "ada@example.com", Password: "s-secret", Retries: 3},
+		"batch", []map[string]string{{"apiKey": "k-secret"}},
+		"plain", map[string]int{"max_tokens": 512})
+
+	// Assert
+	for _, secret := range []string{"m-secret", "s-secret", "ada@example.com", "k-secret"} {
+		assert.NotContains(t, out.String(), secret)
+	}
+	assert.Contains(t, out.String(), `"Accept":"application/json"`)
+	assert.Contains(t, out.String(), `"retries":3`)
+	assert.Contains(t, out.String(), `"plain":{"max_tokens":512}`)
+	unchanged := map[string]int{"max_tokens": 512}
+	assert.Equal(t, unchanged, r.Attr(slog.Any("plain", unchanged)).Value.Any(), "values with nothing to mask are kept as they are")
+}
+
+// TestNewLogger_LogsValuesAsIs_When_RedactionDisabled (ADR-008 Naming)
+func TestNewLogger_LogsValuesAsIs_When_RedactionDisabled(t *testing.T) {
+	// Arrange
+	var out bytes.Buffer
+	r, err := NewRedactor([]string{}, []string{})
+	require.NoError(t, err)
+	logger := slog.New(NewRedactHandler(slog.NewTextHandler(&out, nil), r))
+
+	// Act
+	logger.Info("sent to ada@example.com", "password", "p")
+
+	// Assert
+	assert.Contains(t, out.String(), "ada@example.com")
+	assert.Contains(t, out.String(), "password=p")
+}
+
+// TestNewRedactor_ReturnsError_When_PatternMalformed (ADR-008 Naming)
+func TestNewRedactor_ReturnsError_When_PatternMalformed(t *testing.T) {
+	// Act
+	_, err := NewRedactor(nil, []string{"(unclosed"})
+
+	// Assert
+	assert.Error(t, err)
+}
@@ -5,29 +5,176 @@ package logging
 
 import (
 	"context"
+	"io"
 	"log/slog"
 	"os"
+	"strings"
+
+	"github.com/cockroachdb/errors"
+)
+
+// Formats for Options.Format. FormatCloud is JSON in the Cloud Logging structured
+// format (see NewCloudHandler).
+const (
+	FormatText  = "text"
+	FormatJSON  = "json"
+	FormatCloud = "cloud"
 )
 
+// Outputs for Options.Output; any other value is a file path.
+const (
+	OutputStderr = "stderr"
+	OutputStdout = "stdout"
+)
+
+// Options configures the logger built by NewLogger. Zero values select the defaults:
+// info level, text format, stderr.
+type Options struct {
+	// Level is the minimum level recorded: debug, info, warn or error.
+	Level string
+	// Format is FormatText, FormatJSON or FormatCloud.
+	Format string
+	// AddSource adds the file and line of the logging call to each record.
+	AddSource bool
+	// Output is OutputStderr, OutputStdout or the path of a file to append to.
+	Output string
+	// ProjectID is the Google Cloud project that FormatCloud qualifies trace IDs with.
+	ProjectID string
+	// ComponentLevels overrides Level for the loggers GetLogger returns, keyed by the
+	// component name passed to it or a glob pattern matching it, e.g. {"mcp*": "debug"}.
+	ComponentLevels map[string]string
+	// RedactKeys are the keys whose values are masked, and RedactPatterns the value
+	// patterns masked wherever they appear; see NewRedactor. Nil selects
+	// DefaultRedactKeys and DefaultRedactPatterns, empty masks nothing.
+	RedactKeys     []string
+	RedactPatterns []string
+}
+
 // SlogLogger wraps slog.Logger to implement our Logger interface.
 type SlogLogger struct {
 	logger *slog.Logger
+	// levels holds the component levels, shared by every logger derived from this one.
+	levels *LevelRegistry
 }
 
 // NewSlogLogger creates a new structured logger based on slog.
 // It takes a slog.Level to determine the minimum log level for messages to be recorded.
 func NewSlogLogger(level slog.Level) *SlogLogger {
-	// Create a new handler with the specified level
-	handler := slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{
-		Level: level,
-	})
+	// The registry applies the level; it has no component rules until overridden.
+	levels, _ := NewLevelRegistry(level, nil)
+	handler := NewRedactHandler(slog.NewTextHandler(os.Stderr, nil), defaultRedactor())
+	handler = newComponentLevelHandler(handler, levels)
 
 	// Create a new logger with the handler
 	logger := slog.New(handler)
 
 	return &SlogLogger{
 		logger: logger,
+		levels: levels,
+	}
+}
+
+// NewLogger creates a structured logger from opts. It fails on an unknown level or
+// format, or an output file that cannot be opened. An output file stays open for the
+// life of the process.
+func NewLogger(opts Options) (*SlogLogger, error) {
+	level, components, err := parseLevels(opts)
+	if err != nil {
+		return nil, errors.Wrap(err, "NewLogger: invalid levels")
+	}
+	out, err := openOutput(opts.Output)
+	if err != nil {
+		return nil, err
+	}
+
+	handlerOpts := &slog.HandlerOptions{AddSource: opts.AddSource}
+	var handler slog.Handler
+	switch opts.Format {
+	case "", FormatText:
+		handler = slog.NewTextHandler(out, handlerOpts)
+	case FormatJSON:
+		handler = slog.NewJSONHandler(out, handlerOpts)
+	case FormatCloud:
+		handler = NewCloudHandler(out, CloudHandlerOptions{ProjectID: opts.ProjectID, AddSource: opts.AddSource})
+	default:
+		return nil, errors.Newf("NewLogger: unknown format %q, want text, json or cloud", opts.Format)
+	}
+	keys, patterns := opts.RedactKeys, opts.RedactPatterns
+	if keys == nil {
+		keys = DefaultRedactKeys
+	}
+	if patterns == nil {
+		patterns = DefaultRedactPatterns
+	}
+	redactor, err := NewRedactor(keys, patterns)
+	if err != nil {
+		return nil, errors.Wrap(err, "NewLogger: invalid redaction rules")
+	}
+	levels, err := NewLevelRegistry(level, components)
+	if err != nil {
+		return nil, errors.Wrap(err, "NewLogger: invalid component levels")
+	}
+	// Records are redacted before any format handler, including the cloud handler's
+	// trace expansion, sees them.
+	handler = newComponentLevelHandler(NewRedactHandler(handler, redactor), levels)
+	return &SlogLogger{logger: slog.New(handler), levels: levels}, nil
+}
+
+// Setup builds a logger from opts and makes it the default logger. Loggers obtained
+// from GetLogger before the call keep the previous configuration.
+func Setup(opts Options) error {
+	logger, err := NewLogger(opts)
+	if err != nil {
+		return errors.Wrap(err, "Setup: failed to create logger")
+	}
+	SetDefaultLogger(logger)
+	return nil
+}
+
+// Levels returns the registry holding the component levels of l and of every logger
+// derived from it.
+func (l *SlogLogger) Levels() *LevelRegistry {
+	return l.levels
+}
+
+// DefaultLevels returns the component levels of the default logger, or nil when the
+// default logger is not a SlogLogger.
+func DefaultLevels() *LevelRegistry {
+	if l, ok := defaultLogger.(*SlogLogger); ok {
+		return l.levels
+	}
+	return nil
+}
+
+// ParseLevel parses debug, info, warn or error, in any case. An empty string is info.
+func ParseLevel(level string) (slog.Level, error) {
+	switch strings.ToLower(level) {
+	case "debug":
+		return slog.LevelDebug, nil
+	case "", "info":
+		return slog.LevelInfo, nil
+	case "warn":
+		return slog.LevelWarn, nil
+	case "error":
+		return slog.LevelError, nil
+	default:
+		return 0, errors.Newf("ParseLevel: unknown level %q, want debug, info, warn or error", level)
+	}
+}
+
+// openOutput returns the writer for an Options.Output value.
+func openOutput(output string) (io.Writer, error) {
+	switch output {
+	case "", OutputStderr:
+		return os.Stderr, nil
+	case OutputStdout:
+		return os.Stdout, nil
+	}
+	f, err := os.OpenFile(output, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o644)
+	if err != nil {
+		return nil, errors.Wrapf(err, "openOutput: failed to open log file %s", output)
 	}
+	return f, nil
 }
 
 // Debug logs a debug-level message with the underlying slog logger.
@@ -62,26 +209,18 @@ func (l *SlogLogger) WithField(key string, value any) Logger {
 
 	return &SlogLogger{
 		logger: newLogger,
+		levels: l.levels,
 	}
 }
 
 // SetupDefaultLogger initializes the default logger for the application using SlogLogger.
-// It parses the string log level and configures a global logger instance.
-// Call this early in main() to set up logging for the entire application.
+// It parses the string log level and configures a global logger instance writing text
+// to stderr. main calls it before the configuration is loaded, then Setup with the
+// configured Options.
 func SetupDefaultLogger(level string) {
-	// Convert string level to slog.Level
-	var logLevel slog.Level
-	switch level {
-	case "debug":
-		logLevel = slog.LevelDebug
-	case "info":
-		logLevel = slog.LevelInfo
-	case "warn":
-		logLevel = slog.LevelWarn
-	case "error":
-		logLevel = slog.LevelError
-	default:
-		// Default to info level
+	// Convert string level to slog.Level, defaulting to info.
+	logLevel, err := ParseLevel(level)
+	if err != nil {
 		logLevel = slog.LevelInfo
 	}
 
@@ -90,18 +229,16 @@ func SetupDefaultLogger(level string) {
 	SetDefaultLogger(logger)
 }
 
-// WithContext returns a new SlogLogger instance.
-// For SlogLogger, this implementation primarily satisfies the Logger interface;
-// slog itself does not directly use context for cancellation in its core logging methods
-// in the same way some other logging libraries might. Contextual values can be added
-// using WithField or by passing them as arguments to log methods.
-// Here, "ctx:attached" is added as an example field to indicate context was passed.
-func (l *SlogLogger) WithContext(_ context.Context) Logger { // Renamed ctx to _
-	// slog.Logger doesn't have a dedicated WithContext method for propagation like some loggers.
-	// We return a new logger with a marker field indicating context was considered.
-	// True context propagation for slog would involve extracting values from ctx
-	// and adding them as structured fields.
+// WithContext returns a logger with the correlation fields registered with
+// RegisterContextField that ctx carries, such as the trace ID and the tool name.
+// It returns l itself when ctx carries none.
+func (l *SlogLogger) WithContext(ctx context.Context) Logger {
+	fields := ContextFields(ctx)
+	if len(fields) == 0 {
+		return l
+	}
 	return &SlogLogger{
-		logger: l.logger.With("ctx", "attached"),
+		logger: l.logger.With(fields...),
+		levels: l.levels,
 	}
 }
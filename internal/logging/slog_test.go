@@ -0,0 +1,63 @@
+// file: internal/logging/slog_test.go
+package logging
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestNewLogger_AppliesComponentLevels_When_ComponentLevelsConfigured (ADR-008 Naming)
+func TestNewLogger_AppliesComponentLevels_When_ComponentLevelsConfigured(t *testing.T) {
+	// Arrange
+	path := filepath.Join(t.TempDir(), "app.log")
+	logger, err := NewLogger(Options{
+		Level:           "warn",
+		Format:          FormatJSON,
+		Output:          path,
+		ComponentLevels: map[string]string{"mcp": "debug"},
+	})
+	require.NoError(t, err)
+
+	// Act
+	logger.Info("root info")
+	logger.WithField("component", "config_load").Info("other component info")
+	logger.WithField("component", "config_load").Warn("other component warn")
+	mcp := logger.WithField("component", "mcp").WithField("trace_id", "t-1")
+	mcp.Debug("mcp debug")
+
+	// Assert
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	lines := strings.Split(strings.TrimSpace(string(data)), "\n")
+	require.Len(t, lines, 2)
+	assert.Contains(t, lines[0], `"msg":"other component warn"`)
+	assert.Contains(t, lines[1], `"msg":"mcp debug"`)
+	assert.Contains(t, lines[1], `"trace_id":"t-1"`)
+}
+
+// TestNewLogger_ReturnsError_When_OptionsInvalid (ADR-008 Naming)
+func TestNewLogger_ReturnsError_When_OptionsInvalid(t *testing.T) {
+	tests := []struct {
+		name string
+		opts Options
+	}{
+		{"unknown level", Options{Level: "verbose"}},
+		{"unknown format", Options{Format: "xml"}},
+		{"unknown component level", Options{ComponentLevels: map[string]string{"mcp": "loud"}}},
+		{"unwritable output", Options{Output: filepath.Join(t.TempDir(), "missing", "app.log")}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Act
+			_, err := NewLogger(tt.opts)
+
+			// Assert
+			assert.Error(t, err)
+		})
+	}
+}
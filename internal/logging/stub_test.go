@@ -1,26 +0,0 @@
-package logging
-
-import (
-	"testing" // Import the standard Go testing package
-)
-
-// TestNoTests is a sample test function.
-// Go test functions must start with 'Test', take '*testing.T' as an argument,
-// and reside in a file ending with '_test.go'.
-func TestNoTests(t *testing.T) {
-	// This is a placeholder test.
-	// It doesn't do anything yet.
-	// You might add a t.Skip() if you want to explicitly mark it as skipped for now.
-	// t.Skip("Test not implemented yet")
-
-	// Or, just leave it empty initially.
-	// Actual test logic will go here later.
-	t.Log("No tests written yet.")
-}
-
-// You might add other placeholder test functions below:
-/*
-func TestAnotherFeature(t *testing.T) {
-	t.Skip("Another feature test not implemented yet")
-}
-*/
@@ -0,0 +1,40 @@
+// file: internal/mcp/graph.go
+package mcp
+
+// graph.go renders the session state graph for review (Graphviz DOT and Mermaid).
+
+import (
+	"fmt"
+	"strings"
+)
+
+// SessionGraphDOT renders the session state graph in Graphviz DOT.
+func SessionGraphDOT() string {
+	var b strings.Builder
+	b.WriteString("digraph mcp_session {\n")
+	b.WriteString("    rankdir=LR;\n")
+	b.WriteString("    start [shape=point];\n")
+	fmt.Fprintf(&b, "    %q [shape=doublecircle];\n", StateShutdown)
+	fmt.Fprintf(&b, "    start -> %q;\n", StateUninitialized)
+	for _, t := range transitions {
+		for _, from := range t.From {
+			fmt.Fprintf(&b, "    %q -> %q [label=%q];\n", from, t.To, t.Event)
+		}
+	}
+	b.WriteString("}\n")
+	return b.String()
+}
+
+// SessionGraphMermaid renders the session state graph as a Mermaid state diagram.
+func SessionGraphMermaid() string {
+	var b strings.Builder
+	b.WriteString("stateDiagram-v2\n")
+	fmt.Fprintf(&b, "    [*] --> %s\n", StateUninitialized)
+	for _, t := range transitions {
+		for _, from := range t.From {
+			fmt.Fprintf(&b, "    %s --> %s: %s\n", from, t.To, t.Event)
+		}
+	}
+	fmt.Fprintf(&b, "    %s --> [*]\n", StateShutdown)
+	return b.String()
+}
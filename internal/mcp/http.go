@@ -0,0 +1,111 @@
+// file: internal/mcp/http.go
+package mcp
+
+// http.go serves MCP over the streamable HTTP transport.
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+
+	"github.com/cockroachdb/errors"
+	"github.com/dkoosis/hello-tool-base/internal/apperrors"
+	"github.com/dkoosis/hello-tool-base/internal/jsonrpc"
+	"github.com/dkoosis/hello-tool-base/internal/middleware"
+	"github.com/dkoosis/hello-tool-base/internal/transport"
+)
+
+// SessionHeader carries the session ID on the streamable HTTP transport.
+const SessionHeader = "Mcp-Session-Id"
+
+// HTTPHandler returns the streamable HTTP endpoint. Clients POST one JSON-RPC message
+// per request; requests are answered with an application/json response and
+// notifications with 202 Accepted. The server never initiates messages, so the
+// optional GET event stream is not offered (405, as the specification allows).
+//
+// A successful initialize opens a session whose ID is returned in the Mcp-Session-Id
+// header; later messages must carry it. Unknown session IDs get 404, telling the
+// client to initialize again, and DELETE ends a session. Idle sessions expire and
+// the number of open sessions is capped (see WithSessionLimits). A message larger
+// than transport.MaxMessageSize is answered with an invalid request error naming the
+// limit.
+//
+// It expects the Tracing middleware to have populated the request context.
+func (s *Server) HTTPHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodPost:
+			s.servePost(w, r)
+		case http.MethodDelete:
+			s.serveDelete(w, r)
+		default:
+			w.Header().Set("Allow", http.MethodPost+", "+http.MethodDelete)
+			http.Error(w, "MCP endpoint accepts POST and DELETE only", http.StatusMethodNotAllowed)
+		}
+	})
+}
+
+// servePost handles one JSON-RPC message within its session. Messages without a
+// session ID run in a new session, which is kept only if the message initialized it;
+// anything else sent without a session is rejected as out of sequence.
+func (s *Server) servePost(w http.ResponseWriter, r *http.Request) {
+	logger := middleware.GetLoggerFromContext(r.Context())
+
+	id := r.Header.Get(SessionHeader)
+	var sess *Session
+	if id == "" {
+		sess = s.newSession()
+	} else {
+		found, ok := s.sessions.load(id)
+		if !ok {
+			logger.Warn("Unknown MCP session.", "sessionId", id)
+			http.Error(w, "unknown MCP session", http.StatusNotFound)
+			return
+		}
+		sess = found
+	}
+
+	body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, transport.MaxMessageSize))
+	var tooLarge *http.MaxBytesError
+	if errors.As(err, &tooLarge) {
+		logger.Warn("Rejected oversized MCP message.", "limit", transport.MaxMessageSize)
+		writeResponse(w, logger.Error, jsonrpc.NewError(nil, messageTooLargeError(err)))
+		return
+	}
+	if err != nil {
+		writeResponse(w, logger.Error, jsonrpc.NewError(nil, apperrors.NewParseError("mcp: failed to read request body", err, nil)))
+		return
+	}
+
+	resp := jsonrpc.Dispatch(r.Context(), sess, body)
+	if id == "" && sess.State() != StateUninitialized {
+		s.sessions.store(sess)
+		w.Header().Set(SessionHeader, sess.ID())
+	}
+	if resp == nil {
+		w.WriteHeader(http.StatusAccepted)
+		return
+	}
+	writeResponse(w, logger.Error, resp)
+}
+
+// serveDelete ends the session named in the request header.
+func (s *Server) serveDelete(w http.ResponseWriter, r *http.Request) {
+	sess, ok := s.sessions.delete(r.Header.Get(SessionHeader))
+	if !ok {
+		http.Error(w, "unknown MCP session", http.StatusNotFound)
+		return
+	}
+	sess.Close()
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// writeResponse writes a JSON-RPC response with status 200, as the transport requires
+// even for error responses.
+func writeResponse(w http.ResponseWriter, logError func(msg string, args ...any), resp *jsonrpc.Response) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	if err := json.NewEncoder(w).Encode(resp); err != nil {
+		logError("Failed to encode MCP response.", "error", err)
+	}
+}
@@ -0,0 +1,170 @@
+// file: internal/mcp/integration_test.go
+package mcp_test
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/cockroachdb/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dkoosis/hello-tool-base/internal/apperrors"
+	"github.com/dkoosis/hello-tool-base/internal/jsonrpc"
+	"github.com/dkoosis/hello-tool-base/internal/logging"
+	"github.com/dkoosis/hello-tool-base/internal/mcp"
+	"github.com/dkoosis/hello-tool-base/internal/mcp/mcptest"
+	"github.com/dkoosis/hello-tool-base/internal/tools"
+	"github.com/dkoosis/hello-tool-base/internal/transport"
+	"github.com/dkoosis/hello-tool-base/internal/transport/transporttest"
+)
+
+type upperRequest struct {
+	Text string `query:"text" validate:"required"`
+}
+
+type upperResponse struct {
+	Upper string `json:"upper"`
+}
+
+func newServer(t *testing.T) *mcp.Server {
+	t.Helper()
+	registry := tools.NewRegistry()
+	require.NoError(t, registry.Register(tools.New(
+		tools.Spec{Name: "upper", Method: http.MethodGet, Path: "/upper"},
+		func(_ context.Context, req upperRequest) (upperResponse, error) {
+			return upperResponse{Upper: string(bytes.ToUpper([]byte(req.Text)))}, nil
+		},
+	)))
+	return mcp.NewServer(registry, mcp.Implementation{Name: "test", Version: "1"}, mcp.WithLogger(logging.GetNoopLogger()))
+}
+
+// TestMCP_CallsTool_When_DrivenOverEachInMemoryTransport (ADR-008 Naming)
+func TestMCP_CallsTool_When_DrivenOverEachInMemoryTransport(t *testing.T) {
+	pairs := map[string]func() (transport.Transport, transport.Transport){
+		"channel": func() (transport.Transport, transport.Transport) { return transport.NewChannelPair() },
+		"pipe":    func() (transport.Transport, transport.Transport) { return transport.NewPipePair() },
+	}
+	for name, pair := range pairs {
+		t.Run(name, func(t *testing.T) {
+			// Arrange
+			ctx := context.Background()
+			clientEnd, serverEnd := pair()
+			client := mcptest.StartWith(t, newServer(t), clientEnd, serverEnd)
+			_, err := client.Initialize(ctx)
+			require.NoError(t, err)
+
+			// Act
+			result, err := client.CallTool(ctx, "upper", map[string]string{"text": "hi"})
+
+			// Assert
+			require.NoError(t, err)
+			require.Len(t, result.Content, 1)
+			assert.JSONEq(t, `{"upper":"HI"}`, result.Content[0].Text)
+		})
+	}
+}
+
+// TestMCP_ReportsParseErrorAndContinues_When_FrameIsMalformed (ADR-008 Naming)
+func TestMCP_ReportsParseErrorAndContinues_When_FrameIsMalformed(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	serverEnd, conn := transporttest.NewRawPipe()
+	clientEnd := transport.NewNDJSONTransport(conn, conn, conn)
+	client := mcptest.StartWith(t, newServer(t), clientEnd, serverEnd)
+
+	// Act
+	_, err := conn.Write([]byte("{\"jsonrpc\":\"2.0\",\"id\":1,\"meth\n"))
+	require.NoError(t, err)
+	parseErr, err := client.Receive(ctx)
+	require.NoError(t, err)
+	ping, err := client.Call(ctx, mcp.MethodPing, nil)
+
+	// Assert
+	require.NoError(t, err)
+	require.NotNil(t, parseErr.Error)
+	assert.Equal(t, int(apperrors.ErrParseError), parseErr.Error.Code)
+	assert.Nil(t, ping.Error, "the session must survive a malformed frame")
+}
+
+// TestMCP_ReportsSizeErrorAndContinues_When_FrameExceedsMaxSize (ADR-008 Naming)
+func TestMCP_ReportsSizeErrorAndContinues_When_FrameExceedsMaxSize(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	serverEnd, conn := transporttest.NewRawPipe()
+	clientEnd := transport.NewNDJSONTransport(conn, conn, conn)
+	client := mcptest.StartWith(t, newServer(t), clientEnd, serverEnd)
+
+	// Act
+	_, err := conn.Write(append(bytes.Repeat([]byte("x"), transport.MaxMessageSize+1), '\n'))
+	require.NoError(t, err)
+	sizeErr, err := client.Receive(ctx)
+	require.NoError(t, err)
+	ping, err := client.Call(ctx, mcp.MethodPing, nil)
+
+	// Assert
+	require.NoError(t, err)
+	require.NotNil(t, sizeErr.Error)
+	assert.Equal(t, int(apperrors.ErrInvalidRequest), sizeErr.Error.Code)
+	assert.Contains(t, sizeErr.Error.Data["detail"], "exceeds the limit")
+	assert.Nil(t, ping.Error, "the session must survive an oversized frame")
+}
+
+// TestMCP_TimesOut_When_ClientReadsSlowly (ADR-008 Naming)
+func TestMCP_TimesOut_When_ClientReadsSlowly(t *testing.T) {
+	// Arrange
+	clientEnd, serverEnd := transport.NewChannelPair()
+	slow := transporttest.Inject(clientEnd, transporttest.Faults{ReadDelay: time.Second})
+	client := mcptest.StartWith(t, newServer(t), slow, serverEnd)
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+
+	// Act
+	_, err := client.Call(ctx, mcp.MethodPing, nil)
+
+	// Assert
+	assert.True(t, errors.Is(err, context.DeadlineExceeded))
+}
+
+// TestMCP_ReturnsErrClosed_When_ServerDropsConnection (ADR-008 Naming)
+func TestMCP_ReturnsErrClosed_When_ServerDropsConnection(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	clientEnd, serverEnd := transport.NewChannelPair()
+	dropping := transporttest.Inject(serverEnd, transporttest.Faults{CloseAfterWrites: 1})
+	client := mcptest.StartWith(t, newServer(t), clientEnd, dropping)
+
+	// Act
+	first, firstErr := client.Call(ctx, mcp.MethodPing, nil)
+	_, secondErr := client.Call(ctx, mcp.MethodPing, nil)
+
+	// Assert
+	require.NoError(t, firstErr)
+	assert.Nil(t, first.Error)
+	assert.True(t, errors.Is(secondErr, transport.ErrClosed))
+}
+
+// TestMCP_RejectsLaterCalls_When_HandshakeIsCorrupted (ADR-008 Naming)
+func TestMCP_RejectsLaterCalls_When_HandshakeIsCorrupted(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	clientEnd, serverEnd := transport.NewChannelPair()
+	corrupt := transporttest.Inject(clientEnd, transporttest.Faults{Corrupt: func(msg []byte) []byte {
+		return bytes.Replace(msg, []byte(`"initialize"`), []byte(`"initialise"`), 1)
+	}})
+	client := mcptest.StartWith(t, newServer(t), corrupt, serverEnd)
+
+	// Act
+	_, initErr := client.Initialize(ctx)
+	_, listErr := client.ListTools(ctx)
+
+	// Assert
+	var rpcErr *jsonrpc.Error
+	require.True(t, errors.As(initErr, &rpcErr))
+	assert.Equal(t, int(apperrors.ErrRequestSequence), rpcErr.Code)
+	require.True(t, errors.As(listErr, &rpcErr))
+	assert.Equal(t, int(apperrors.ErrRequestSequence), rpcErr.Code)
+}
@@ -0,0 +1,175 @@
+// Package mcptest provides a minimal MCP client for driving an mcp.Server end to end,
+// in-process, over any transport (typically one from transport.NewChannelPair or
+// transport.NewPipePair).
+// file: internal/mcp/mcptest/mcptest.go
+package mcptest
+
+import (
+	"context"
+	"encoding/json"
+	"strconv"
+	"sync"
+	"testing"
+
+	"github.com/cockroachdb/errors"
+	"github.com/dkoosis/hello-tool-base/internal/jsonrpc"
+	"github.com/dkoosis/hello-tool-base/internal/mcp"
+	"github.com/dkoosis/hello-tool-base/internal/transport"
+)
+
+// Client sends JSON-RPC messages over a transport and waits for matching responses.
+// Calls are serialized: a Client has at most one request in flight.
+type Client struct {
+	t transport.Transport
+
+	mu     sync.Mutex
+	nextID int
+}
+
+// NewClient creates a client speaking over t.
+func NewClient(t transport.Transport) *Client {
+	return &Client{t: t}
+}
+
+// Start serves srv over an in-memory channel pair and returns a client connected to it.
+// The connection is closed when the test ends, and Serve must then return without error.
+func Start(tb testing.TB, srv *mcp.Server) *Client {
+	tb.Helper()
+	clientEnd, serverEnd := transport.NewChannelPair()
+	return StartWith(tb, srv, clientEnd, serverEnd)
+}
+
+// StartWith serves srv over serverEnd and returns a client using clientEnd. Tests use it
+// to pick the transport variant or to wrap either end with transporttest.Inject.
+func StartWith(tb testing.TB, srv *mcp.Server, clientEnd, serverEnd transport.Transport) *Client {
+	tb.Helper()
+	done := make(chan error, 1)
+	go func() { done <- srv.Serve(context.Background(), serverEnd) }()
+	tb.Cleanup(func() {
+		_ = clientEnd.Close()
+		_ = serverEnd.Close()
+		if err := <-done; err != nil {
+			tb.Errorf("mcptest: Serve returned an error: %+v", err)
+		}
+	})
+	return NewClient(clientEnd)
+}
+
+// Send writes a raw message without waiting for a response.
+func (c *Client) Send(ctx context.Context, msg []byte) error {
+	return c.t.WriteMessage(ctx, msg)
+}
+
+// Receive reads the next raw message and decodes it as a response.
+func (c *Client) Receive(ctx context.Context) (*jsonrpc.Response, error) {
+	data, err := c.t.ReadMessage(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var resp jsonrpc.Response
+	if err := json.Unmarshal(data, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// Call sends a request and returns its response, which may carry a JSON-RPC error.
+// The returned error reports transport or encoding failures only.
+func (c *Client) Call(ctx context.Context, method string, params any) (*jsonrpc.Response, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.nextID++
+	id := json.RawMessage(strconv.Itoa(c.nextID))
+	msg, err := encode(id, method, params)
+	if err != nil {
+		return nil, err
+	}
+	if err := c.t.WriteMessage(ctx, msg); err != nil {
+		return nil, err
+	}
+	for {
+		resp, err := c.Receive(ctx)
+		if err != nil {
+			return nil, err
+		}
+		if string(resp.ID) == string(id) {
+			return resp, nil
+		}
+	}
+}
+
+// Notify sends a notification.
+func (c *Client) Notify(ctx context.Context, method string, params any) error {
+	msg, err := encode(nil, method, params)
+	if err != nil {
+		return err
+	}
+	return c.t.WriteMessage(ctx, msg)
+}
+
+// Initialize performs the MCP handshake: initialize followed by notifications/initialized.
+func (c *Client) Initialize(ctx context.Context) (*mcp.InitializeResult, error) {
+	var result mcp.InitializeResult
+	err := c.callResult(ctx, mcp.MethodInitialize, mcp.InitializeParams{
+		ProtocolVersion: mcp.LatestProtocolVersion,
+		ClientInfo:      mcp.Implementation{Name: "mcptest", Version: "0"},
+	}, &result)
+	if err != nil {
+		return nil, err
+	}
+	if err := c.Notify(ctx, mcp.MethodInitialized, nil); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// ListTools calls tools/list.
+func (c *Client) ListTools(ctx context.Context) (*mcp.ListToolsResult, error) {
+	var result mcp.ListToolsResult
+	if err := c.callResult(ctx, mcp.MethodToolsList, nil, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// CallTool calls tools/call. A JSON-RPC error response is returned as a *jsonrpc.Error.
+func (c *Client) CallTool(ctx context.Context, name string, args any) (*mcp.CallToolResult, error) {
+	raw, err := json.Marshal(args)
+	if err != nil {
+		return nil, err
+	}
+	var result mcp.CallToolResult
+	if err := c.callResult(ctx, mcp.MethodToolsCall, mcp.CallToolParams{Name: name, Arguments: raw}, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// callResult calls method and decodes a successful result into out.
+func (c *Client) callResult(ctx context.Context, method string, params, out any) error {
+	resp, err := c.Call(ctx, method, params)
+	if err != nil {
+		return err
+	}
+	if resp.Error != nil {
+		return resp.Error
+	}
+	if len(resp.Result) == 0 {
+		return errors.New("mcptest: response has neither result nor error")
+	}
+	return json.Unmarshal(resp.Result, out)
+}
+
+// encode builds a JSON-RPC request, or a notification when id is nil.
+func encode(id json.RawMessage, method string, params any) ([]byte, error) {
+	req := jsonrpc.Request{JSONRPC: jsonrpc.Version, ID: id, Method: method}
+	if params != nil {
+		raw, err := json.Marshal(params)
+		if err != nil {
+			return nil, err
+		}
+		req.Params = raw
+	}
+	return json.Marshal(req)
+}
@@ -0,0 +1,241 @@
+// Package mcp exposes the service's registered tools over the Model Context Protocol
+// (JSON-RPC 2.0 with initialize, tools/list and tools/call). Each connection is a
+// Session whose state machine rejects out-of-order messages. The same tools.Registry
+// that serves the REST routes backs the MCP tools, so handlers, input schemas and
+// validation are shared; errors are reported through apperrors.MapAppErrorToJSONRPC.
+// The server runs over any transport.Transport (e.g. NDJSON on stdio) or as a
+// streamable HTTP endpoint.
+// file: internal/mcp/server.go
+package mcp
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/cockroachdb/errors"
+	"github.com/dkoosis/hello-tool-base/internal/apperrors"
+	"github.com/dkoosis/hello-tool-base/internal/jsonrpc"
+	"github.com/dkoosis/hello-tool-base/internal/logging"
+	"github.com/dkoosis/hello-tool-base/internal/middleware"
+	"github.com/dkoosis/hello-tool-base/internal/tools"
+	"github.com/dkoosis/hello-tool-base/internal/transport"
+	"github.com/google/uuid"
+)
+
+// Server answers MCP requests using the tools in a registry.
+type Server struct {
+	registry     *tools.Registry
+	info         Implementation
+	instructions string
+	logger       logging.Logger
+
+	// sessions holds the open streamable HTTP sessions by ID.
+	sessions *sessionStore
+}
+
+// Option configures a Server.
+type Option func(*Server)
+
+// WithLogger sets the base logger for request-scoped MCP loggers.
+func WithLogger(logger logging.Logger) Option {
+	return func(s *Server) {
+		if logger != nil {
+			s.logger = logger
+		}
+	}
+}
+
+// WithInstructions sets the usage instructions returned to clients on initialize.
+func WithInstructions(instructions string) Option {
+	return func(s *Server) {
+		s.instructions = instructions
+	}
+}
+
+// WithSessionLimits bounds the streamable HTTP sessions: a session unused for
+// idleTimeout expires, and opening one beyond maxSessions evicts the least recently
+// used. Non-positive values keep DefaultSessionIdleTimeout and DefaultMaxSessions.
+func WithSessionLimits(idleTimeout time.Duration, maxSessions int) Option {
+	return func(s *Server) {
+		if idleTimeout > 0 {
+			s.sessions.idleTimeout = idleTimeout
+		}
+		if maxSessions > 0 {
+			s.sessions.maxSessions = maxSessions
+		}
+	}
+}
+
+// NewServer creates an MCP server exposing the tools in registry.
+func NewServer(registry *tools.Registry, info Implementation, opts ...Option) *Server {
+	s := &Server{registry: registry, info: info, logger: logging.GetLogger("mcp")}
+	s.sessions = newSessionStore()
+	for _, opt := range opts {
+		opt(s)
+	}
+	s.sessions.logger = s.logger
+	return s
+}
+
+// newSession starts a session with a fresh identifier.
+func (s *Server) newSession() *Session {
+	return newSession(uuid.NewString(), s)
+}
+
+// handle dispatches a single MCP method. Sequencing is enforced by the Session.
+func (s *Server) handle(ctx context.Context, req *jsonrpc.Request) (any, error) {
+	logger := middleware.GetLoggerFromContext(ctx)
+	logger.Debug("Handling MCP message.", "method", req.Method, "notification", req.IsNotification())
+
+	switch req.Method {
+	case MethodInitialize:
+		return s.initialize(req.Params)
+	case MethodInitialized, MethodCancelled:
+		return nil, nil
+	case MethodPing:
+		return struct{}{}, nil
+	case MethodToolsList:
+		return s.listTools(), nil
+	case MethodToolsCall:
+		return s.callTool(ctx, req.Params)
+	default:
+		return nil, apperrors.NewMethodNotFoundError("mcp: unsupported method "+req.Method, nil,
+			map[string]interface{}{"method": req.Method})
+	}
+}
+
+// initialize negotiates the protocol version and advertises server capabilities.
+func (s *Server) initialize(raw json.RawMessage) (*InitializeResult, error) {
+	var params InitializeParams
+	if err := decodeParams(raw, &params); err != nil {
+		return nil, err
+	}
+	version := LatestProtocolVersion
+	for _, v := range supportedProtocolVersions {
+		if v == params.ProtocolVersion {
+			version = v
+		}
+	}
+	s.logger.Info("MCP client initializing.",
+		"client", params.ClientInfo.Name,
+		"clientVersion", params.ClientInfo.Version,
+		"requestedProtocolVersion", params.ProtocolVersion,
+		"protocolVersion", version,
+	)
+	return &InitializeResult{
+		ProtocolVersion: version,
+		Capabilities:    ServerCapabilities{Tools: &ToolsCapability{}},
+		ServerInfo:      s.info,
+		Instructions:    s.instructions,
+	}, nil
+}
+
+// listTools describes every registered tool.
+func (s *Server) listTools() *ListToolsResult {
+	registered := s.registry.Tools()
+	result := &ListToolsResult{Tools: make([]Tool, 0, len(registered))}
+	for _, t := range registered {
+		result.Tools = append(result.Tools, Tool{
+			Name:         t.Name,
+			Title:        t.Summary,
+			Description:  t.Description,
+			InputSchema:  t.InputSchema(),
+			OutputSchema: t.OutputSchema(),
+		})
+	}
+	return result
+}
+
+// callTool invokes a registered tool with the given arguments.
+func (s *Server) callTool(ctx context.Context, raw json.RawMessage) (*CallToolResult, error) {
+	var params CallToolParams
+	if err := decodeParams(raw, &params); err != nil {
+		return nil, err
+	}
+	t, ok := s.registry.Lookup(params.Name)
+	if !ok {
+		return nil, apperrors.NewInvalidParamsError("mcp: unknown tool "+params.Name, nil,
+			map[string]interface{}{"toolName": params.Name})
+	}
+
+	result, err := t.Call(ctx, params.Arguments)
+	if err != nil {
+		return nil, err
+	}
+	text, err := json.Marshal(result)
+	if err != nil {
+		return nil, apperrors.NewInternalError("mcp: failed to encode tool result", err,
+			map[string]interface{}{"toolName": params.Name})
+	}
+	return &CallToolResult{
+		Content:           []Content{{Type: "text", Text: string(text)}},
+		StructuredContent: result,
+	}, nil
+}
+
+// Serve reads messages from t and writes responses until t is closed or ctx is done.
+// The transport is one session; each message is handled with its own trace ID and
+// request-scoped logger. A message larger than transport.MaxMessageSize is answered
+// with an invalid request error and skipped. A closed transport or cancelled context
+// ends Serve without error.
+func (s *Server) Serve(ctx context.Context, t transport.Transport) error {
+	stop := context.AfterFunc(ctx, func() { _ = t.Close() })
+	defer stop()
+
+	sess := s.newSession()
+	defer sess.Close()
+
+	s.logger.Info("MCP server serving transport.", "sessionId", sess.ID())
+	for {
+		msg, err := t.ReadMessage(ctx)
+		var resp *jsonrpc.Response
+		switch {
+		case errors.Is(err, transport.ErrMessageTooLarge):
+			// The transport has skipped the message; answer it and read the next one.
+			s.logger.Warn("Skipped oversized MCP message.", "limit", transport.MaxMessageSize)
+			resp = jsonrpc.NewError(nil, messageTooLargeError(err))
+		case err != nil:
+			if errors.Is(err, transport.ErrClosed) || ctx.Err() != nil {
+				s.logger.Info("MCP transport closed, stopping.")
+				return nil
+			}
+			return errors.Wrap(err, "Server.Serve: failed to read message")
+		default:
+			msgCtx := middleware.ContextWithTrace(ctx, s.logger, middleware.NewTraceID())
+			resp = jsonrpc.Dispatch(msgCtx, sess, msg)
+		}
+		if resp == nil {
+			continue
+		}
+		out, err := json.Marshal(resp)
+		if err != nil {
+			return errors.Wrap(err, "Server.Serve: failed to encode response")
+		}
+		if err := t.WriteMessage(ctx, out); err != nil {
+			if errors.Is(err, transport.ErrClosed) || ctx.Err() != nil {
+				return nil
+			}
+			return errors.Wrap(err, "Server.Serve: failed to write response")
+		}
+	}
+}
+
+// messageTooLargeError reports a message larger than transport.MaxMessageSize.
+func messageTooLargeError(cause error) error {
+	return apperrors.NewInvalidRequestError(
+		fmt.Sprintf("mcp: message exceeds the limit of %d bytes", transport.MaxMessageSize), cause,
+		map[string]interface{}{"limit": transport.MaxMessageSize})
+}
+
+// decodeParams unmarshals request params into v, reporting failures as invalid params.
+func decodeParams(raw json.RawMessage, v any) error {
+	if len(raw) == 0 {
+		return nil
+	}
+	if err := json.Unmarshal(raw, v); err != nil {
+		return apperrors.NewInvalidParamsError("mcp: malformed params", err, nil)
+	}
+	return nil
+}
@@ -0,0 +1,229 @@
+// file: internal/mcp/server_test.go
+package mcp
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dkoosis/hello-tool-base/internal/apperrors"
+	"github.com/dkoosis/hello-tool-base/internal/jsonrpc"
+	"github.com/dkoosis/hello-tool-base/internal/logging"
+	"github.com/dkoosis/hello-tool-base/internal/tools"
+	"github.com/dkoosis/hello-tool-base/internal/transport"
+)
+
+type echoRequest struct {
+	Text string `query:"text" validate:"required,maxLength=10"`
+}
+
+type echoResponse struct {
+	Echo string `json:"echo"`
+}
+
+func newTestServer(t *testing.T) *Server {
+	t.Helper()
+	registry := tools.NewRegistry()
+	require.NoError(t, registry.Register(tools.New(
+		tools.Spec{Name: "echo", Method: http.MethodGet, Path: "/echo", Summary: "Echo text"},
+		func(_ context.Context, req echoRequest) (echoResponse, error) {
+			return echoResponse{Echo: req.Text}, nil
+		},
+	)))
+	return NewServer(registry, Implementation{Name: "test", Version: "1.0.0"}, WithLogger(logging.GetNoopLogger()))
+}
+
+// TestServer_AnswersSession_When_ServedOverNDJSON (ADR-008 Naming)
+func TestServer_AnswersSession_When_ServedOverNDJSON(t *testing.T) {
+	// Arrange
+	in := strings.Join([]string{
+		`{"jsonrpc":"2.0","id":1,"method":"initialize","params":{"protocolVersion":"2025-03-26","clientInfo":{"name":"c","version":"1"}}}`,
+		`{"jsonrpc":"2.0","method":"notifications/initialized"}`,
+		`{"jsonrpc":"2.0","id":2,"method":"tools/list"}`,
+		`{"jsonrpc":"2.0","id":3,"method":"tools/call","params":{"name":"echo","arguments":{"text":"hi"}}}`,
+	}, "\n") + "\n"
+	var out bytes.Buffer
+	tr := transport.NewNDJSONTransport(strings.NewReader(in), &out, nil)
+
+	// Act
+	err := newTestServer(t).Serve(context.Background(), tr)
+
+	// Assert
+	require.NoError(t, err)
+	var responses []jsonrpc.Response
+	scanner := bufio.NewScanner(&out)
+	for scanner.Scan() {
+		var resp jsonrpc.Response
+		require.NoError(t, json.Unmarshal(scanner.Bytes(), &resp))
+		require.Nil(t, resp.Error)
+		responses = append(responses, resp)
+	}
+	require.Len(t, responses, 3, "the notification must not be answered")
+
+	var initResult InitializeResult
+	require.NoError(t, json.Unmarshal(responses[0].Result, &initResult))
+	assert.Equal(t, "2025-03-26", initResult.ProtocolVersion)
+	assert.NotNil(t, initResult.Capabilities.Tools)
+
+	var list ListToolsResult
+	require.NoError(t, json.Unmarshal(responses[1].Result, &list))
+	require.Len(t, list.Tools, 1)
+	assert.Equal(t, "echo", list.Tools[0].Name)
+	assert.Equal(t, []string{"text"}, list.Tools[0].InputSchema.Required)
+
+	var call CallToolResult
+	require.NoError(t, json.Unmarshal(responses[2].Result, &call))
+	require.Len(t, call.Content, 1)
+	assert.JSONEq(t, `{"echo":"hi"}`, call.Content[0].Text)
+	assert.False(t, call.IsError)
+}
+
+// post sends one message to the streamable HTTP handler within sessionID (if set).
+func post(handler http.Handler, sessionID, body string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodPost, "/mcp", strings.NewReader(body))
+	if sessionID != "" {
+		req.Header.Set(SessionHeader, sessionID)
+	}
+	rr := httptest.NewRecorder()
+	handler.ServeHTTP(rr, req)
+	return rr
+}
+
+// initializeHTTP completes the handshake over HTTP and returns the session ID.
+func initializeHTTP(t *testing.T, handler http.Handler) string {
+	t.Helper()
+	rr := post(handler, "", `{"jsonrpc":"2.0","id":0,"method":"initialize","params":{"protocolVersion":"2025-06-18"}}`)
+	require.Equal(t, http.StatusOK, rr.Code)
+	sessionID := rr.Header().Get(SessionHeader)
+	require.NotEmpty(t, sessionID)
+	require.Equal(t, http.StatusAccepted, post(handler, sessionID, `{"jsonrpc":"2.0","method":"notifications/initialized"}`).Code)
+	return sessionID
+}
+
+// TestServer_ReturnsInvalidParams_When_ArgumentsFailValidation (ADR-008 Naming)
+func TestServer_ReturnsInvalidParams_When_ArgumentsFailValidation(t *testing.T) {
+	// Arrange
+	handler := newTestServer(t).HTTPHandler()
+	sessionID := initializeHTTP(t, handler)
+	body := `{"jsonrpc":"2.0","id":"a","method":"tools/call","params":{"name":"echo","arguments":{"text":"far too long"}}}`
+
+	// Act
+	rr := post(handler, sessionID, body)
+
+	// Assert
+	assert.Equal(t, http.StatusOK, rr.Code)
+	var resp jsonrpc.Response
+	require.NoError(t, json.NewDecoder(rr.Body).Decode(&resp))
+	require.NotNil(t, resp.Error)
+	assert.Equal(t, int(apperrors.ErrInvalidParams), resp.Error.Code)
+}
+
+// TestServer_AcceptsNotification_When_PostedOverHTTP (ADR-008 Naming)
+func TestServer_AcceptsNotification_When_PostedOverHTTP(t *testing.T) {
+	// Arrange
+	handler := newTestServer(t).HTTPHandler()
+	sessionID := initializeHTTP(t, handler)
+
+	// Act
+	rr := post(handler, sessionID, `{"jsonrpc":"2.0","method":"notifications/cancelled","params":{"requestId":1}}`)
+
+	// Assert
+	assert.Equal(t, http.StatusAccepted, rr.Code)
+	assert.Empty(t, rr.Body.String())
+}
+
+// TestServer_ReturnsNotFound_When_SessionWasDeleted (ADR-008 Naming)
+func TestServer_ReturnsNotFound_When_SessionWasDeleted(t *testing.T) {
+	// Arrange
+	handler := newTestServer(t).HTTPHandler()
+	sessionID := initializeHTTP(t, handler)
+	req := httptest.NewRequest(http.MethodDelete, "/mcp", nil)
+	req.Header.Set(SessionHeader, sessionID)
+	deleted := httptest.NewRecorder()
+	handler.ServeHTTP(deleted, req)
+
+	// Act
+	rr := post(handler, sessionID, `{"jsonrpc":"2.0","id":1,"method":"ping"}`)
+
+	// Assert
+	assert.Equal(t, http.StatusNoContent, deleted.Code)
+	assert.Equal(t, http.StatusNotFound, rr.Code)
+}
+
+// TestServer_ExpiresSession_When_IdleLongerThanTimeout (ADR-008 Naming)
+func TestServer_ExpiresSession_When_IdleLongerThanTimeout(t *testing.T) {
+	// Arrange
+	server := newTestServer(t)
+	WithSessionLimits(time.Minute, 0)(server)
+	now := time.Unix(0, 0)
+	server.sessions.now = func() time.Time { return now }
+	handler := server.HTTPHandler()
+	active := initializeHTTP(t, handler)
+	idle := initializeHTTP(t, handler)
+
+	// Act
+	now = now.Add(40 * time.Second)
+	kept := post(handler, active, `{"jsonrpc":"2.0","id":1,"method":"ping"}`)
+	now = now.Add(40 * time.Second)
+	expired := post(handler, idle, `{"jsonrpc":"2.0","id":2,"method":"ping"}`)
+
+	// Assert
+	assert.Equal(t, http.StatusOK, kept.Code)
+	assert.Equal(t, http.StatusNotFound, expired.Code)
+	assert.Equal(t, 1, server.sessions.count())
+	assert.Equal(t, http.StatusOK, post(handler, active, `{"jsonrpc":"2.0","id":3,"method":"ping"}`).Code)
+}
+
+// TestServer_EvictsLeastRecentlyUsedSession_When_MaxSessionsReached (ADR-008 Naming)
+func TestServer_EvictsLeastRecentlyUsedSession_When_MaxSessionsReached(t *testing.T) {
+	// Arrange
+	server := newTestServer(t)
+	WithSessionLimits(0, 2)(server)
+	now := time.Unix(0, 0)
+	server.sessions.now = func() time.Time { return now }
+	handler := server.HTTPHandler()
+	first := initializeHTTP(t, handler)
+	now = now.Add(time.Second)
+	second := initializeHTTP(t, handler)
+	now = now.Add(time.Second)
+	require.Equal(t, http.StatusOK, post(handler, first, `{"jsonrpc":"2.0","id":1,"method":"ping"}`).Code)
+
+	// Act
+	now = now.Add(time.Second)
+	third := initializeHTTP(t, handler)
+
+	// Assert
+	assert.Equal(t, 2, server.sessions.count())
+	assert.Equal(t, http.StatusNotFound, post(handler, second, `{"jsonrpc":"2.0","id":2,"method":"ping"}`).Code)
+	assert.Equal(t, http.StatusOK, post(handler, first, `{"jsonrpc":"2.0","id":3,"method":"ping"}`).Code)
+	assert.Equal(t, http.StatusOK, post(handler, third, `{"jsonrpc":"2.0","id":4,"method":"ping"}`).Code)
+}
+
+// TestServer_ReturnsSizeError_When_HTTPMessageExceedsMaxSize (ADR-008 Naming)
+func TestServer_ReturnsSizeError_When_HTTPMessageExceedsMaxSize(t *testing.T) {
+	// Arrange
+	handler := newTestServer(t).HTTPHandler()
+	sessionID := initializeHTTP(t, handler)
+	body := `{"jsonrpc":"2.0","id":1,"method":"tools/call","params":{"name":"echo","arguments":{"text":"` +
+		strings.Repeat("x", transport.MaxMessageSize) + `"}}}`
+
+	// Act
+	rr := post(handler, sessionID, body)
+
+	// Assert
+	assert.Equal(t, http.StatusOK, rr.Code)
+	var resp jsonrpc.Response
+	require.NoError(t, json.NewDecoder(rr.Body).Decode(&resp))
+	require.NotNil(t, resp.Error)
+	assert.Equal(t, int(apperrors.ErrInvalidRequest), resp.Error.Code)
+	assert.Contains(t, resp.Error.Data["detail"], "exceeds the limit of 4194304 bytes")
+}
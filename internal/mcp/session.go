@@ -0,0 +1,230 @@
+// file: internal/mcp/session.go
+package mcp
+
+// session.go tracks each connection's protocol state and rejects out-of-order messages.
+
+import (
+	"context"
+	"sync"
+
+	"github.com/dkoosis/hello-tool-base/internal/apperrors"
+	"github.com/dkoosis/hello-tool-base/internal/jsonrpc"
+	"github.com/dkoosis/hello-tool-base/internal/logging"
+	"github.com/dkoosis/hello-tool-base/internal/middleware"
+)
+
+// sessionContextKey is the context key of the ID of the session handling a message.
+type sessionContextKey struct{}
+
+// Loggers enriched with Logger.WithContext include the session ID.
+func init() {
+	logging.RegisterContextField("sessionId", logging.StringFromContext(sessionContextKey{}))
+}
+
+// State is a session's position in the MCP lifecycle.
+type State string
+
+// Session states, in lifecycle order.
+const (
+	StateUninitialized State = "Uninitialized"
+	StateInitializing  State = "Initializing"
+	StateInitialized   State = "Initialized"
+	StateShuttingDown  State = "ShuttingDown"
+	StateShutdown      State = "Shutdown"
+)
+
+// Event triggers a session state transition.
+type Event string
+
+// Session events.
+const (
+	// EventInitialize fires when the server has answered an initialize request.
+	EventInitialize Event = "initialize"
+	// EventInitialized fires when the client confirms with notifications/initialized.
+	EventInitialized Event = "initialized"
+	// EventShutdown fires when the connection starts closing.
+	EventShutdown Event = "shutdown"
+	// EventClosed fires once the connection is fully closed.
+	EventClosed Event = "closed"
+)
+
+// transition moves a session from any of From to To when Event fires.
+type transition struct {
+	Event Event
+	From  []State
+	To    State
+}
+
+// transitions is the session state graph. It is the single source for both
+// enforcement and the exported diagrams.
+var transitions = []transition{
+	{Event: EventInitialize, From: []State{StateUninitialized}, To: StateInitializing},
+	{Event: EventInitialized, From: []State{StateInitializing}, To: StateInitialized},
+	{Event: EventShutdown, From: []State{StateUninitialized, StateInitializing, StateInitialized}, To: StateShuttingDown},
+	{Event: EventClosed, From: []State{StateShuttingDown}, To: StateShutdown},
+}
+
+// methodEvents maps the handshake methods to the event fired once they succeed.
+var methodEvents = map[string]Event{
+	MethodInitialize:  EventInitialize,
+	MethodInitialized: EventInitialized,
+}
+
+// preInitMethods lists the methods accepted before the handshake completes.
+// Once Initialized, every method except the handshake itself is accepted
+// (unknown methods are then reported as method not found).
+var preInitMethods = map[State]map[string]bool{
+	StateUninitialized: {MethodInitialize: true, MethodPing: true},
+	StateInitializing:  {MethodInitialized: true, MethodPing: true, MethodCancelled: true},
+}
+
+// allows reports whether a message for method is valid in state s.
+func (s State) allows(method string) bool {
+	switch s {
+	case StateInitialized:
+		_, handshake := methodEvents[method]
+		return !handshake
+	case StateShuttingDown, StateShutdown:
+		return false
+	default:
+		return preInitMethods[s][method]
+	}
+}
+
+// Session is one client connection. It validates every incoming method against the
+// current state and implements jsonrpc.Handler on behalf of its Server.
+type Session struct {
+	id     string
+	server *Server
+	logger logging.Logger
+
+	mu    sync.Mutex
+	state State
+}
+
+// newSession creates a session in the Uninitialized state.
+func newSession(id string, server *Server) *Session {
+	return &Session{
+		id:     id,
+		server: server,
+		logger: server.logger.WithField("sessionId", id),
+		state:  StateUninitialized,
+	}
+}
+
+// ID returns the session identifier.
+func (sess *Session) ID() string {
+	return sess.id
+}
+
+// State returns the current state.
+func (sess *Session) State() State {
+	sess.mu.Lock()
+	defer sess.mu.Unlock()
+	return sess.state
+}
+
+// HandleRPC implements jsonrpc.Handler. Methods that are not valid in the current state
+// are rejected with apperrors.ErrRequestSequence. Handshake methods advance the state in
+// the same critical section as that check, so a concurrent duplicate is rejected; if
+// the handler then fails, the state reverts.
+func (sess *Session) HandleRPC(ctx context.Context, req *jsonrpc.Request) (any, error) {
+	ctx = context.WithValue(ctx, sessionContextKey{}, sess.id)
+	logger := middleware.GetLoggerFromContext(ctx)
+
+	from, reserved, err := sess.reserve(req.Method)
+	if err != nil {
+		logger.Warn("Rejected MCP message out of sequence.", "method", req.Method, "state", from)
+		return nil, err
+	}
+
+	result, err := sess.server.handle(ctx, req)
+	if err != nil {
+		if reserved != "" {
+			sess.revert(reserved, from)
+		}
+		return nil, err
+	}
+	return result, nil
+}
+
+// reserve checks that method is valid in the current state and, for a handshake
+// method, fires its event. It returns the state before and, if the event fired, the
+// state it moved to.
+func (sess *Session) reserve(method string) (from, reserved State, err error) {
+	sess.mu.Lock()
+	defer sess.mu.Unlock()
+	from = sess.state
+	if !from.allows(method) {
+		return from, "", sequenceError("mcp: "+method+" is not allowed in state "+string(from), method, from)
+	}
+	event, ok := methodEvents[method]
+	if !ok {
+		return from, "", nil
+	}
+	if err := sess.fireLocked(event); err != nil {
+		return from, "", err
+	}
+	return from, sess.state, nil
+}
+
+// revert moves the session back to from after a failed handshake method, unless the
+// state has changed again since reserve moved it to reserved.
+func (sess *Session) revert(reserved, from State) {
+	sess.mu.Lock()
+	defer sess.mu.Unlock()
+	if sess.state != reserved {
+		return
+	}
+	sess.state = from
+	sess.logger.Info("MCP session state reverted.", "from", reserved, "to", from)
+}
+
+// Fire applies event, returning an apperrors.ErrRequestSequence error if the event is
+// not valid in the current state.
+func (sess *Session) Fire(event Event) error {
+	sess.mu.Lock()
+	defer sess.mu.Unlock()
+	return sess.fireLocked(event)
+}
+
+// fireLocked applies event with sess.mu held.
+func (sess *Session) fireLocked(event Event) error {
+	from := sess.state
+	for _, t := range transitions {
+		if t.Event != event {
+			continue
+		}
+		for _, s := range t.From {
+			if s == from {
+				sess.state = t.To
+				sess.logger.Info("MCP session state changed.", "event", event, "from", from, "to", t.To)
+				return nil
+			}
+		}
+	}
+	return sequenceError("mcp: event "+string(event)+" is not allowed in state "+string(from), "", from)
+}
+
+// Close moves the session through ShuttingDown to Shutdown. Closing a session that is
+// already shut down has no effect.
+func (sess *Session) Close() {
+	sess.mu.Lock()
+	defer sess.mu.Unlock()
+	if sess.state == StateShutdown {
+		return
+	}
+	if sess.state != StateShuttingDown {
+		_ = sess.fireLocked(EventShutdown)
+	}
+	_ = sess.fireLocked(EventClosed)
+}
+
+// sequenceError reports a message or event that is invalid for the session state.
+func sequenceError(message, method string, state State) error {
+	details := map[string]interface{}{"state": string(state)}
+	if method != "" {
+		details["method"] = method
+	}
+	return apperrors.NewProtocolError(apperrors.ErrRequestSequence, message, nil, details)
+}
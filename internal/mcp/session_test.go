@@ -0,0 +1,147 @@
+// file: internal/mcp/session_test.go
+package mcp
+
+import (
+	"context"
+	"flag"
+	"os"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dkoosis/hello-tool-base/internal/apperrors"
+	"github.com/dkoosis/hello-tool-base/internal/jsonrpc"
+)
+
+var update = flag.Bool("update", false, "rewrite docs/mcp-session-states.md from the session state graph")
+
+const sessionStatesDoc = "../../docs/mcp-session-states.md"
+
+func call(t *testing.T, sess *Session, method, params string) *jsonrpc.Response {
+	t.Helper()
+	msg := `{"jsonrpc":"2.0","id":1,"method":"` + method + `"`
+	if params != "" {
+		msg += `,"params":` + params
+	}
+	return jsonrpc.Dispatch(context.Background(), sess, []byte(msg+"}"))
+}
+
+// TestSession_RejectsToolCall_When_NotInitialized (ADR-008 Naming)
+func TestSession_RejectsToolCall_When_NotInitialized(t *testing.T) {
+	// Arrange
+	sess := newTestServer(t).newSession()
+
+	// Act
+	resp := call(t, sess, MethodToolsCall, `{"name":"echo","arguments":{"text":"hi"}}`)
+
+	// Assert
+	require.NotNil(t, resp.Error)
+	assert.Equal(t, int(apperrors.ErrRequestSequence), resp.Error.Code)
+	assert.Equal(t, StateUninitialized, sess.State())
+}
+
+// TestSession_FollowsLifecycle_When_HandshakeCompletes (ADR-008 Naming)
+func TestSession_FollowsLifecycle_When_HandshakeCompletes(t *testing.T) {
+	// Arrange
+	sess := newTestServer(t).newSession()
+
+	// Act
+	initResp := call(t, sess, MethodInitialize, `{"protocolVersion":"2025-06-18"}`)
+	afterInitialize := sess.State()
+	earlyList := call(t, sess, MethodToolsList, "")
+	jsonrpc.Dispatch(context.Background(), sess, []byte(`{"jsonrpc":"2.0","method":"notifications/initialized"}`))
+	afterInitialized := sess.State()
+	list := call(t, sess, MethodToolsList, "")
+	again := call(t, sess, MethodInitialize, `{"protocolVersion":"2025-06-18"}`)
+	sess.Close()
+
+	// Assert
+	assert.Nil(t, initResp.Error)
+	assert.Equal(t, StateInitializing, afterInitialize)
+	require.NotNil(t, earlyList.Error, "tools must wait for notifications/initialized")
+	assert.Equal(t, int(apperrors.ErrRequestSequence), earlyList.Error.Code)
+	assert.Equal(t, StateInitialized, afterInitialized)
+	assert.Nil(t, list.Error)
+	require.NotNil(t, again.Error, "a session is initialized only once")
+	assert.Equal(t, int(apperrors.ErrRequestSequence), again.Error.Code)
+	assert.Equal(t, StateShutdown, sess.State())
+}
+
+// TestSession_InitializesOnce_When_InitializeRequestsRace (ADR-008 Naming)
+func TestSession_InitializesOnce_When_InitializeRequestsRace(t *testing.T) {
+	// Arrange
+	sess := newTestServer(t).newSession()
+	const callers = 8
+	responses := make(chan *jsonrpc.Response, callers)
+	var wg sync.WaitGroup
+
+	// Act
+	for range callers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			responses <- call(t, sess, MethodInitialize, `{"protocolVersion":"2025-06-18"}`)
+		}()
+	}
+	wg.Wait()
+	close(responses)
+
+	// Assert
+	succeeded := 0
+	for resp := range responses {
+		if resp.Error == nil {
+			succeeded++
+			continue
+		}
+		assert.Equal(t, int(apperrors.ErrRequestSequence), resp.Error.Code)
+	}
+	assert.Equal(t, 1, succeeded)
+	assert.Equal(t, StateInitializing, sess.State())
+}
+
+// TestSession_StaysUninitialized_When_InitializeFails (ADR-008 Naming)
+func TestSession_StaysUninitialized_When_InitializeFails(t *testing.T) {
+	// Arrange
+	sess := newTestServer(t).newSession()
+
+	// Act
+	failed := call(t, sess, MethodInitialize, `"not an object"`)
+	afterFailure := sess.State()
+	retried := call(t, sess, MethodInitialize, `{"protocolVersion":"2025-06-18"}`)
+
+	// Assert
+	require.NotNil(t, failed.Error)
+	assert.Equal(t, int(apperrors.ErrInvalidParams), failed.Error.Code)
+	assert.Equal(t, StateUninitialized, afterFailure)
+	assert.Nil(t, retried.Error)
+	assert.Equal(t, StateInitializing, sess.State())
+}
+
+// TestSessionGraph_MatchesCommittedDoc_When_GeneratedFromTransitions (ADR-008 Naming)
+func TestSessionGraph_MatchesCommittedDoc_When_GeneratedFromTransitions(t *testing.T) {
+	// Arrange
+	var b strings.Builder
+	b.WriteString("# MCP Session States\n\n")
+	b.WriteString("<!-- Code generated from internal/mcp/session.go. DO NOT EDIT. -->\n")
+	b.WriteString("<!-- Regenerate with: go test ./internal/mcp -run TestSessionGraph -update -->\n\n")
+	b.WriteString("Every MCP connection (a stdio transport or a streamable HTTP session) follows this\n")
+	b.WriteString("state machine. Messages that are not valid in the current state are rejected with\n")
+	b.WriteString("`apperrors.ErrRequestSequence` (-32001).\n\n")
+	b.WriteString("```mermaid\n" + SessionGraphMermaid() + "```\n\n")
+	b.WriteString("Graphviz:\n\n")
+	b.WriteString("```dot\n" + SessionGraphDOT() + "```\n")
+	generated := b.String()
+
+	// Act
+	if *update {
+		require.NoError(t, os.WriteFile(sessionStatesDoc, []byte(generated), 0o644))
+	}
+	committed, err := os.ReadFile(sessionStatesDoc)
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, generated, string(committed), "session state docs are stale; rerun with -update")
+}
@@ -0,0 +1,134 @@
+// file: internal/mcp/sessions.go
+package mcp
+
+// sessions.go keeps the open streamable HTTP sessions and expires idle ones.
+
+import (
+	"sync"
+	"time"
+
+	"github.com/dkoosis/hello-tool-base/internal/logging"
+)
+
+const (
+	// DefaultSessionIdleTimeout is how long an HTTP session may go unused before it
+	// expires.
+	DefaultSessionIdleTimeout = 30 * time.Minute
+	// DefaultMaxSessions is the number of HTTP sessions kept open at once.
+	DefaultMaxSessions = 1000
+)
+
+// storedSession is an open session and the time it was last used.
+type storedSession struct {
+	sess     *Session
+	lastUsed time.Time
+}
+
+// sessionStore holds the open HTTP sessions by ID. Sessions unused for idleTimeout
+// expire, and opening one beyond maxSessions evicts the least recently used. Expired
+// sessions are swept at most once per half idleTimeout, from the requests themselves,
+// so no goroutine outlives the server.
+type sessionStore struct {
+	idleTimeout time.Duration
+	maxSessions int
+	// logger is the server's, set once its options are applied.
+	logger logging.Logger
+	now    func() time.Time
+
+	mu        sync.Mutex
+	byID      map[string]*storedSession
+	lastSweep time.Time
+}
+
+// newSessionStore returns an empty store with the default idle timeout and session limit.
+func newSessionStore() *sessionStore {
+	return &sessionStore{
+		idleTimeout: DefaultSessionIdleTimeout,
+		maxSessions: DefaultMaxSessions,
+		now:         time.Now,
+		byID:        make(map[string]*storedSession),
+	}
+}
+
+// load returns the open session id and marks it used. It returns false for unknown
+// and expired sessions.
+func (st *sessionStore) load(id string) (*Session, bool) {
+	st.mu.Lock()
+	defer st.mu.Unlock()
+	now := st.now()
+	st.sweepLocked(now)
+	stored, ok := st.byID[id]
+	if !ok {
+		return nil, false
+	}
+	if st.expired(stored, now) {
+		st.removeLocked(id, "idle", now)
+		return nil, false
+	}
+	stored.lastUsed = now
+	return stored.sess, true
+}
+
+// store adds sess, evicting the least recently used session if the store is full.
+func (st *sessionStore) store(sess *Session) {
+	st.mu.Lock()
+	defer st.mu.Unlock()
+	now := st.now()
+	st.sweepLocked(now)
+	for len(st.byID) >= st.maxSessions {
+		var oldest string
+		for id, stored := range st.byID {
+			if oldest == "" || stored.lastUsed.Before(st.byID[oldest].lastUsed) {
+				oldest = id
+			}
+		}
+		st.removeLocked(oldest, "limit", now)
+	}
+	st.byID[sess.ID()] = &storedSession{sess: sess, lastUsed: now}
+}
+
+// delete removes the session id and reports whether it was open. The caller closes it.
+func (st *sessionStore) delete(id string) (*Session, bool) {
+	st.mu.Lock()
+	defer st.mu.Unlock()
+	stored, ok := st.byID[id]
+	if !ok {
+		return nil, false
+	}
+	delete(st.byID, id)
+	return stored.sess, true
+}
+
+// count returns the number of open sessions, expired or not.
+func (st *sessionStore) count() int {
+	st.mu.Lock()
+	defer st.mu.Unlock()
+	return len(st.byID)
+}
+
+// expired reports whether stored has been unused for idleTimeout as of now.
+func (st *sessionStore) expired(stored *storedSession, now time.Time) bool {
+	return now.Sub(stored.lastUsed) >= st.idleTimeout
+}
+
+// sweepLocked closes the expired sessions, unless the last sweep was recent.
+func (st *sessionStore) sweepLocked(now time.Time) {
+	if now.Sub(st.lastSweep) < st.idleTimeout/2 {
+		return
+	}
+	st.lastSweep = now
+	for id, stored := range st.byID {
+		if st.expired(stored, now) {
+			st.removeLocked(id, "idle", now)
+		}
+	}
+}
+
+// removeLocked closes and forgets the session id, logging why.
+func (st *sessionStore) removeLocked(id, reason string, now time.Time) {
+	stored := st.byID[id]
+	delete(st.byID, id)
+	st.logger.Info("MCP session evicted.", "sessionId", id, "reason", reason,
+		"idle", now.Sub(stored.lastUsed).String())
+	stored.sess.Close()
+}
@@ -0,0 +1,92 @@
+// file: internal/mcp/types.go
+package mcp
+
+// types.go defines the Model Context Protocol messages this server understands.
+
+import (
+	"encoding/json"
+
+	"github.com/dkoosis/hello-tool-base/internal/openapi"
+)
+
+// MCP method names.
+const (
+	MethodInitialize  = "initialize"
+	MethodInitialized = "notifications/initialized"
+	MethodPing        = "ping"
+	MethodToolsList   = "tools/list"
+	MethodToolsCall   = "tools/call"
+	MethodCancelled   = "notifications/cancelled"
+)
+
+// LatestProtocolVersion is the newest MCP revision this server implements. It is offered
+// to clients that request a revision the server does not support.
+const LatestProtocolVersion = "2025-06-18"
+
+// supportedProtocolVersions lists the MCP revisions a client may negotiate.
+var supportedProtocolVersions = []string{LatestProtocolVersion, "2025-03-26", "2024-11-05"}
+
+// Implementation identifies an MCP client or server.
+type Implementation struct {
+	Name    string `json:"name"`
+	Version string `json:"version"`
+}
+
+// InitializeParams are the parameters of the initialize request.
+type InitializeParams struct {
+	ProtocolVersion string          `json:"protocolVersion"`
+	Capabilities    json.RawMessage `json:"capabilities,omitempty"`
+	ClientInfo      Implementation  `json:"clientInfo"`
+}
+
+// InitializeResult is the server's answer to initialize.
+type InitializeResult struct {
+	ProtocolVersion string             `json:"protocolVersion"`
+	Capabilities    ServerCapabilities `json:"capabilities"`
+	ServerInfo      Implementation     `json:"serverInfo"`
+	Instructions    string             `json:"instructions,omitempty"`
+}
+
+// ServerCapabilities advertises the features the server supports.
+type ServerCapabilities struct {
+	Tools *ToolsCapability `json:"tools,omitempty"`
+}
+
+// ToolsCapability describes the server's tool support.
+type ToolsCapability struct {
+	ListChanged bool `json:"listChanged"`
+}
+
+// Tool describes a callable tool in a tools/list result.
+type Tool struct {
+	Name         string          `json:"name"`
+	Title        string          `json:"title,omitempty"`
+	Description  string          `json:"description,omitempty"`
+	InputSchema  *openapi.Schema `json:"inputSchema"`
+	OutputSchema *openapi.Schema `json:"outputSchema,omitempty"`
+}
+
+// ListToolsResult is the result of tools/list.
+type ListToolsResult struct {
+	Tools []Tool `json:"tools"`
+}
+
+// CallToolParams are the parameters of tools/call.
+type CallToolParams struct {
+	Name      string          `json:"name"`
+	Arguments json.RawMessage `json:"arguments,omitempty"`
+}
+
+// CallToolResult is the result of tools/call. The structured result is also rendered
+// as JSON text for clients that only read content blocks.
+type CallToolResult struct {
+	Content           []Content `json:"content"`
+	StructuredContent any       `json:"structuredContent,omitempty"`
+	IsError           bool      `json:"isError,omitempty"`
+}
+
+// Content is a content block of a tool result.
+type Content struct {
+	Type string `json:"type"`
+	Text string `json:"text"`
+}
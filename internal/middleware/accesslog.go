@@ -0,0 +1,82 @@
+// file: internal/middleware/accesslog.go
+package middleware
+
+// accesslog.go logs one record per completed request, with the request described in
+// the Cloud Logging httpRequest form.
+
+import (
+	"net"
+	"net/http"
+	"time"
+
+	"github.com/dkoosis/hello-tool-base/internal/logging"
+)
+
+// AccessLog returns middleware that logs "Request completed." on the request logger
+// once the handler returns, with a logging.HTTPRequest under logging.HTTPRequestKey.
+//
+// It must run after Tracing so the request-scoped logger is available.
+func AccessLog() func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			start := time.Now()
+			rec := &statusRecorder{ResponseWriter: w}
+			next.ServeHTTP(rec, r)
+			if rec.status == 0 {
+				rec.status = http.StatusOK // The handler wrote nothing.
+			}
+			GetLoggerFromContext(r.Context()).Info("Request completed.", logging.HTTPRequestKey, logging.HTTPRequest{
+				Method:       r.Method,
+				URL:          r.URL.String(),
+				Status:       rec.status,
+				RequestSize:  r.ContentLength,
+				ResponseSize: rec.size,
+				UserAgent:    r.UserAgent(),
+				RemoteIP:     remoteIP(r.RemoteAddr),
+				Referer:      r.Referer(),
+				Protocol:     r.Proto,
+				Latency:      time.Since(start),
+			})
+		})
+	}
+}
+
+// remoteIP returns the IP address of a request's RemoteAddr without its port, or
+// addr itself when it is not a host:port pair.
+func remoteIP(addr string) string {
+	host, _, err := net.SplitHostPort(addr)
+	if err != nil {
+		return addr
+	}
+	return host
+}
+
+// statusRecorder records the status code and body size written through it.
+type statusRecorder struct {
+	http.ResponseWriter
+	status int
+	size   int64
+}
+
+// WriteHeader records the status code of the first call.
+func (s *statusRecorder) WriteHeader(status int) {
+	if s.status == 0 {
+		s.status = status
+	}
+	s.ResponseWriter.WriteHeader(status)
+}
+
+// Write counts body bytes, implicitly recording a 200 status.
+func (s *statusRecorder) Write(p []byte) (int, error) {
+	if s.status == 0 {
+		s.status = http.StatusOK
+	}
+	n, err := s.ResponseWriter.Write(p)
+	s.size += int64(n)
+	return n, err
+}
+
+// Unwrap exposes the underlying writer to http.ResponseController, e.g. for flushing.
+func (s *statusRecorder) Unwrap() http.ResponseWriter {
+	return s.ResponseWriter
+}
@@ -0,0 +1,40 @@
+// file: internal/middleware/auth.go
+package middleware
+
+// auth.go protects operator endpoints with a static bearer token.
+
+import (
+	"crypto/subtle"
+	"net/http"
+	"strings"
+
+	"github.com/dkoosis/hello-tool-base/internal/apperrors"
+	"github.com/dkoosis/hello-tool-base/internal/respond"
+)
+
+// RequireBearerToken returns middleware that rejects requests whose Authorization header
+// does not carry "Bearer <token>" with 401 and the standard error body. The comparison
+// takes constant time. An empty token rejects every request.
+//
+// It must run after Tracing so the request-scoped logger is available.
+func RequireBearerToken(token string) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			logger := GetLoggerFromContext(r.Context())
+			details := map[string]interface{}{"requested_path": r.URL.Path}
+
+			presented, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+			if !ok || presented == "" {
+				w.Header().Set("WWW-Authenticate", "Bearer")
+				respond.Error(logger, w, apperrors.NewAuthError(apperrors.ErrAuthMissing, "RequireBearerToken: missing bearer token", nil, details))
+				return
+			}
+			if token == "" || subtle.ConstantTimeCompare([]byte(presented), []byte(token)) != 1 {
+				w.Header().Set("WWW-Authenticate", "Bearer")
+				respond.Error(logger, w, apperrors.NewAuthError(apperrors.ErrAuthInvalid, "RequireBearerToken: invalid bearer token", nil, details))
+				return
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
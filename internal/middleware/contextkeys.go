@@ -4,6 +4,8 @@
 // file: internal/middleware/contextkeys.go
 package middleware
 
+import "github.com/dkoosis/hello-tool-base/internal/logging"
+
 // contextKey is an unexported type for context keys to avoid collisions.
 // This prevents other packages from using the same key names to store values in the context,
 // ensuring that middleware-specific values are not accidentally overwritten or accessed.
@@ -22,4 +24,20 @@ var (
 	// TraceIDContextKey is the context key used to store and retrieve the
 	// trace ID associated with a request within a context.Context.
 	TraceIDContextKey = contextKey("traceID")
+	// SpanIDContextKey is the context key of the span ID from the request's
+	// X-Cloud-Trace-Context header, as 16 hex digits.
+	SpanIDContextKey = contextKey("spanID")
+	// CallerContextKey is the context key of the caller identity set by Identity.
+	CallerContextKey = contextKey("caller")
+	// TenantContextKey is the context key of the tenant set by Identity.
+	TenantContextKey = contextKey("tenant")
 )
+
+// The values stored under these keys are added to every logger enriched with
+// Logger.WithContext, including the one GetLoggerFromContext returns.
+func init() {
+	logging.RegisterContextField(logging.TraceIDKey, logging.StringFromContext(TraceIDContextKey))
+	logging.RegisterContextField(logging.SpanIDKey, logging.StringFromContext(SpanIDContextKey))
+	logging.RegisterContextField(logging.CallerKey, logging.StringFromContext(CallerContextKey))
+	logging.RegisterContextField(logging.TenantKey, logging.StringFromContext(TenantContextKey))
+}
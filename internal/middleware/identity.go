@@ -0,0 +1,47 @@
+// file: internal/middleware/identity.go
+package middleware
+
+// identity.go records who a request comes from, for logs and feature flags.
+
+import (
+	"context"
+	"net/http"
+)
+
+// Identity returns middleware that stores the values of the callerHeader and
+// tenantHeader request headers in the request context, under CallerContextKey and
+// TenantContextKey. An empty header name skips that value. The values are
+// self-declared by the client and identify, not authenticate, it.
+func Identity(callerHeader, tenantHeader string) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			ctx := r.Context()
+			if v := header(r, callerHeader); v != "" {
+				ctx = context.WithValue(ctx, CallerContextKey, v)
+			}
+			if v := header(r, tenantHeader); v != "" {
+				ctx = context.WithValue(ctx, TenantContextKey, v)
+			}
+			next.ServeHTTP(w, r.WithContext(ctx))
+		})
+	}
+}
+
+func header(r *http.Request, name string) string {
+	if name == "" {
+		return ""
+	}
+	return r.Header.Get(name)
+}
+
+// GetCallerFromContext returns the caller identity stored by Identity, or "".
+func GetCallerFromContext(ctx context.Context) string {
+	caller, _ := ctx.Value(CallerContextKey).(string)
+	return caller
+}
+
+// GetTenantFromContext returns the tenant stored by Identity, or "".
+func GetTenantFromContext(ctx context.Context) string {
+	tenant, _ := ctx.Value(TenantContextKey).(string)
+	return tenant
+}
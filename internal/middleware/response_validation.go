@@ -0,0 +1,113 @@
+// file: internal/middleware/response_validation.go
+package middleware
+
+// response_validation.go checks outgoing responses against the service's OpenAPI description.
+
+import (
+	"bytes"
+	"net/http"
+	"strings"
+
+	"github.com/dkoosis/hello-tool-base/internal/apperrors"
+	"github.com/dkoosis/hello-tool-base/internal/openapi"
+	"github.com/dkoosis/hello-tool-base/internal/respond"
+)
+
+// ResponseValidationOptions configures ResponseValidation.
+type ResponseValidationOptions struct {
+	// Strict replaces a non-conforming response with a 500 whose details list every violation.
+	// Otherwise the original response is sent unchanged and the violations are only logged.
+	Strict bool
+	// OnViolation, if set, is called for every non-conforming response,
+	// e.g. to record violations in tests or metrics.
+	OnViolation func(r *http.Request, status int, violations []apperrors.Violation)
+}
+
+// ResponseValidation returns middleware that buffers each response and checks its status
+// code and body against the matching response schema in doc. It is intended for development
+// and test environments: buffering defeats streaming and adds a copy per request. Requests
+// for routes doc does not describe, such as /mcp and /admin, pass through unwrapped, so
+// their handlers can still stream.
+//
+// It must run after Tracing so the request-scoped logger is available.
+func ResponseValidation(doc *openapi.Document, opts ResponseValidationOptions) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if op, _, _ := doc.FindOperation(r.Method, r.URL.Path); op == nil {
+				next.ServeHTTP(w, r)
+				return
+			}
+			buf := &bufferedResponse{header: make(http.Header), status: http.StatusOK}
+			next.ServeHTTP(buf, r)
+
+			violations, matched := doc.ValidateResponse(r.Method, r.URL.Path, buf.status, buf.header.Get("Content-Type"), buf.body.Bytes())
+			if !matched || len(violations) == 0 {
+				buf.flushTo(w)
+				return
+			}
+
+			logger := GetLoggerFromContext(r.Context())
+			logger.Warn("Response does not conform to the OpenAPI schema.",
+				"method", r.Method,
+				"path", r.URL.Path,
+				"status", buf.status,
+				"violations", violations,
+			)
+			if opts.OnViolation != nil {
+				opts.OnViolation(r, buf.status, violations)
+			}
+			if !opts.Strict {
+				buf.flushTo(w)
+				return
+			}
+			respond.JSON(logger, w, http.StatusInternalServerError, respond.ErrorResponse{
+				Error:      "Response Schema Violation",
+				Details:    DescribeViolations(violations),
+				Code:       int(apperrors.ErrInternalError),
+				Violations: violations,
+			})
+		})
+	}
+}
+
+// DescribeViolations renders violations as a readable diff, one "pointer: message" per line.
+func DescribeViolations(violations []apperrors.Violation) string {
+	lines := make([]string, 0, len(violations))
+	for _, v := range violations {
+		lines = append(lines, v.Pointer+": "+v.Message)
+	}
+	return strings.Join(lines, "\n")
+}
+
+// bufferedResponse captures a handler's response so it can be inspected before sending.
+type bufferedResponse struct {
+	header http.Header
+	status int
+	body   bytes.Buffer
+	wrote  bool
+}
+
+// Header returns the buffered response headers.
+func (b *bufferedResponse) Header() http.Header { return b.header }
+
+// WriteHeader records the status code of the first call, like http.ResponseWriter.
+func (b *bufferedResponse) WriteHeader(status int) {
+	if !b.wrote {
+		b.status, b.wrote = status, true
+	}
+}
+
+// Write buffers body bytes, implicitly recording a 200 status.
+func (b *bufferedResponse) Write(p []byte) (int, error) {
+	b.WriteHeader(http.StatusOK)
+	return b.body.Write(p)
+}
+
+// flushTo sends the buffered response to w.
+func (b *bufferedResponse) flushTo(w http.ResponseWriter) {
+	for k, v := range b.header {
+		w.Header()[k] = v
+	}
+	w.WriteHeader(b.status)
+	_, _ = w.Write(b.body.Bytes())
+}
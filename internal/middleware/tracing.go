@@ -28,33 +28,47 @@ const (
 // otherwise, it generates a new UUID-based trace ID. This trace ID is then set
 // in the X-Trace-ID response header.
 //
-// Furthermore, it creates a request-scoped logger enriched with this trace ID
-// and adds both the trace ID and the logger to the request's context.
-// The baseLogger provided is used as the foundation for these request-scoped loggers.
+// Furthermore, it adds the trace ID, the span ID when the header has one, and
+// baseLogger to the request's context; GetLoggerFromContext enriches baseLogger with
+// them for each request.
 func Tracing(baseLogger logging.Logger) func(http.Handler) http.Handler {
 	return func(next http.Handler) http.Handler {
 		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 			traceID := r.Header.Get(HeaderCloudTraceContext)
 			if traceID == "" {
-				traceID = fmt.Sprintf("generated-%s", uuid.New().String())
+				traceID = NewTraceID()
 			}
 			w.Header().Set(HeaderTraceID, traceID)
 
-			ctx := context.WithValue(r.Context(), TraceIDContextKey, traceID)
-
-			// Create request-scoped logger WITH ONLY trace_id added by middleware.
-			// The baseLogger already has its component (e.g., "app" or "hello-tool-base-main").
-			// Handlers will add their specific context (like "handler":"helloHandler").
-			requestLogger := baseLogger.WithField("trace_id", traceID)
-			ctx = context.WithValue(ctx, LoggerContextKey, requestLogger)
-
+			ctx := ContextWithTrace(r.Context(), baseLogger, traceID)
 			next.ServeHTTP(w, r.WithContext(ctx))
 		})
 	}
 }
 
-// GetLoggerFromContext retrieves the request-scoped logger from the provided context.Context.
-// It expects the logger to have been set using the LoggerContextKey by the Tracing middleware (or similar).
+// NewTraceID generates a trace ID for work that did not arrive with one.
+func NewTraceID() string {
+	return fmt.Sprintf("generated-%s", uuid.New().String())
+}
+
+// ContextWithTrace stores traceID, the span ID it carries, and baseLogger in ctx.
+// Tracing uses it for HTTP requests; other transports (e.g. MCP over stdio) call it
+// directly so handlers see the same context values regardless of how they were invoked.
+func ContextWithTrace(ctx context.Context, baseLogger logging.Logger, traceID string) context.Context {
+	ctx = context.WithValue(ctx, TraceIDContextKey, traceID)
+	if _, span, _ := logging.ParseTraceContext(traceID); span != "" {
+		ctx = context.WithValue(ctx, SpanIDContextKey, span)
+	}
+
+	// The logger is stored without request fields: GetLoggerFromContext adds them, so
+	// values stored in the context later, such as the tool name, are included too.
+	// The baseLogger already has its component (e.g., "app" or "hello-tool-base-main").
+	return context.WithValue(ctx, LoggerContextKey, baseLogger)
+}
+
+// GetLoggerFromContext returns the request-scoped logger: the logger stored by the
+// Tracing middleware (or ContextWithTrace), enriched with Logger.WithContext(ctx) with
+// the correlation fields in ctx, such as trace_id, caller and tool.
 // If no logger is found in the context (which ideally should not happen if middleware is correctly applied),
 // it returns a fallback logger and logs a warning about the missing logger.
 func GetLoggerFromContext(ctx context.Context) logging.Logger {
@@ -62,11 +76,11 @@ func GetLoggerFromContext(ctx context.Context) logging.Logger {
 	if !ok || logger == nil {
 		// This should ideally not happen if middleware is correctly applied.
 		// Fallback to a default logger or a new one with a warning.
-		fallbackLogger := logging.GetLogger("context_logger_fallback")
+		fallbackLogger := logging.GetLogger("context_logger_fallback").WithContext(ctx)
 		fallbackLogger.Warn("Logger not found in context, using fallback.")
 		return fallbackLogger
 	}
-	return logger
+	return logger.WithContext(ctx)
 }
 
 // GetTraceIDFromContext retrieves the trace ID from the provided context.Context.
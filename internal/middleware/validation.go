@@ -0,0 +1,69 @@
+// file: internal/middleware/validation.go
+package middleware
+
+// validation.go validates incoming requests against the service's OpenAPI description.
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/cockroachdb/errors"
+	"github.com/dkoosis/hello-tool-base/internal/apperrors"
+	"github.com/dkoosis/hello-tool-base/internal/openapi"
+	"github.com/dkoosis/hello-tool-base/internal/respond"
+)
+
+// MaxRequestBodySize is the largest request body, in bytes, OpenAPIValidation reads.
+const MaxRequestBodySize = 4 << 20
+
+// OpenAPIValidation returns middleware that validates each request's path, query and
+// header parameters and JSON body against the matching operation in doc before the
+// handler runs. Requests that violate the schema are rejected with the standard error
+// body and an apperrors.ErrInvalidParams code listing every violation with its JSON pointer.
+// Requests for routes the document does not describe are passed through unchanged.
+// Bodies larger than MaxRequestBodySize are rejected the same way, with a violation
+// at /body, before more than the limit is read.
+//
+// It must run after Tracing so the request-scoped logger is available.
+func OpenAPIValidation(doc *openapi.Document) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			logger := GetLoggerFromContext(r.Context())
+
+			if r.Body != nil {
+				r.Body = http.MaxBytesReader(w, r.Body, MaxRequestBodySize)
+			}
+			violations, matched, err := doc.ValidateRequest(r)
+			var tooLarge *http.MaxBytesError
+			if errors.As(err, &tooLarge) {
+				respond.Error(logger, w, apperrors.NewValidationError(
+					"OpenAPIValidation: request body exceeds the size limit",
+					[]apperrors.Violation{{
+						Field: "body", In: openapi.InBody, Pointer: openapi.Pointer(openapi.InBody),
+						Message: fmt.Sprintf("The request body must be at most %d bytes.", MaxRequestBodySize),
+					}},
+					map[string]interface{}{"method": r.Method, "requested_path": r.URL.Path}))
+				return
+			}
+			if err != nil {
+				respond.Error(logger, w, apperrors.NewInvalidRequestError("OpenAPIValidation: failed to read request", err,
+					map[string]interface{}{"method": r.Method, "requested_path": r.URL.Path}))
+				return
+			}
+			if !matched {
+				logger.Debug("No OpenAPI operation matches request, skipping validation.", "method", r.Method, "path", r.URL.Path)
+				next.ServeHTTP(w, r)
+				return
+			}
+			if len(violations) > 0 {
+				respond.Error(logger, w, apperrors.NewValidationError(
+					fmt.Sprintf("OpenAPIValidation: request does not conform to the OpenAPI schema (%d violations)", len(violations)),
+					violations,
+					map[string]interface{}{"method": r.Method, "requested_path": r.URL.Path},
+				))
+				return
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
@@ -0,0 +1,151 @@
+// Package openapi models the subset of the OpenAPI 3.0 specification used to describe
+// this service's tools to Vertex AI. Documents are generated from the registered tools
+// (see tools.Registry.OpenAPI), marshalled to the committed openapi.yaml and served at
+// runtime, so the published contract always matches the binary.
+// file: internal/openapi/document.go
+package openapi
+
+import (
+	"bytes"
+	"net/http"
+
+	"github.com/cockroachdb/errors"
+	"gopkg.in/yaml.v3"
+)
+
+// Version is the OpenAPI specification version emitted by this package.
+const Version = "3.0.3"
+
+// Document is the root object of an OpenAPI description.
+type Document struct {
+	OpenAPI    string               `yaml:"openapi" json:"openapi"`
+	Info       Info                 `yaml:"info" json:"info"`
+	Servers    []Server             `yaml:"servers,omitempty" json:"servers,omitempty"`
+	Paths      map[string]*PathItem `yaml:"paths" json:"paths"`
+	Components *Components          `yaml:"components,omitempty" json:"components,omitempty"`
+}
+
+// Info describes the API, including the Vertex AI tool extensions.
+type Info struct {
+	Title           string `yaml:"title" json:"title"`
+	Description     string `yaml:"description,omitempty" json:"description,omitempty"`
+	Version         string `yaml:"version" json:"version"`
+	ToolName        string `yaml:"x-google-vertex-ai-tool-name,omitempty" json:"x-google-vertex-ai-tool-name,omitempty"`
+	ToolDescription string `yaml:"x-google-vertex-ai-tool-description,omitempty" json:"x-google-vertex-ai-tool-description,omitempty"`
+}
+
+// Server is a base URL at which the API is reachable.
+type Server struct {
+	URL         string `yaml:"url" json:"url"`
+	Description string `yaml:"description,omitempty" json:"description,omitempty"`
+}
+
+// PathItem holds the operations available on a single path.
+type PathItem struct {
+	Get    *Operation `yaml:"get,omitempty" json:"get,omitempty"`
+	Put    *Operation `yaml:"put,omitempty" json:"put,omitempty"`
+	Post   *Operation `yaml:"post,omitempty" json:"post,omitempty"`
+	Delete *Operation `yaml:"delete,omitempty" json:"delete,omitempty"`
+	Patch  *Operation `yaml:"patch,omitempty" json:"patch,omitempty"`
+}
+
+// Operation describes a single API operation on a path.
+type Operation struct {
+	Summary     string               `yaml:"summary,omitempty" json:"summary,omitempty"`
+	Description string               `yaml:"description,omitempty" json:"description,omitempty"`
+	OperationID string               `yaml:"operationId" json:"operationId"`
+	Parameters  []*Parameter         `yaml:"parameters,omitempty" json:"parameters,omitempty"`
+	RequestBody *RequestBody         `yaml:"requestBody,omitempty" json:"requestBody,omitempty"`
+	Responses   map[string]*Response `yaml:"responses" json:"responses"`
+}
+
+// Parameter describes a single path, query or header parameter.
+type Parameter struct {
+	Name        string  `yaml:"name" json:"name"`
+	In          string  `yaml:"in" json:"in"`
+	Required    bool    `yaml:"required,omitempty" json:"required,omitempty"`
+	Description string  `yaml:"description,omitempty" json:"description,omitempty"`
+	Schema      *Schema `yaml:"schema" json:"schema"`
+}
+
+// RequestBody describes a JSON request body.
+type RequestBody struct {
+	Description string                `yaml:"description,omitempty" json:"description,omitempty"`
+	Required    bool                  `yaml:"required,omitempty" json:"required,omitempty"`
+	Content     map[string]*MediaType `yaml:"content" json:"content"`
+}
+
+// Response describes a single response from an operation.
+type Response struct {
+	Description string                `yaml:"description" json:"description"`
+	Content     map[string]*MediaType `yaml:"content,omitempty" json:"content,omitempty"`
+}
+
+// MediaType pairs a content type with its schema.
+type MediaType struct {
+	Schema *Schema `yaml:"schema" json:"schema"`
+}
+
+// Components holds reusable schemas referenced with $ref.
+type Components struct {
+	Schemas map[string]*Schema `yaml:"schemas,omitempty" json:"schemas,omitempty"`
+}
+
+// Operation returns the operation for an HTTP method, or nil if the path item has none.
+func (p *PathItem) Operation(method string) *Operation {
+	switch method {
+	case http.MethodGet, http.MethodHead:
+		return p.Get
+	case http.MethodPut:
+		return p.Put
+	case http.MethodPost:
+		return p.Post
+	case http.MethodDelete:
+		return p.Delete
+	case http.MethodPatch:
+		return p.Patch
+	default:
+		return nil
+	}
+}
+
+// SetOperation sets the operation for an HTTP method.
+// It returns an error for methods the document model does not support.
+func (p *PathItem) SetOperation(method string, op *Operation) error {
+	switch method {
+	case http.MethodGet:
+		p.Get = op
+	case http.MethodPut:
+		p.Put = op
+	case http.MethodPost:
+		p.Post = op
+	case http.MethodDelete:
+		p.Delete = op
+	case http.MethodPatch:
+		p.Patch = op
+	default:
+		return errors.Newf("PathItem.SetOperation: unsupported HTTP method %q", method)
+	}
+	return nil
+}
+
+// generatedHeader is prepended to marshalled documents so readers know not to edit the file by hand.
+const generatedHeader = "# Code generated from the registered tools. DO NOT EDIT.\n" +
+	"# Regenerate with: go test ./cmd/hello-tool-base -run TestOpenAPISpec -update\n"
+
+// Marshal renders the document as YAML, preceded by a generated-file header.
+// Map keys are emitted in sorted order, so the output is deterministic.
+func (d *Document) Marshal() ([]byte, error) {
+	var buf bytes.Buffer
+	buf.WriteString(generatedHeader)
+	buf.WriteString("---\n")
+	enc := yaml.NewEncoder(&buf)
+	enc.SetIndent(2)
+	if err := enc.Encode(d); err != nil {
+		return nil, errors.Wrap(err, "Document.Marshal: failed to encode OpenAPI document as YAML")
+	}
+	if err := enc.Close(); err != nil {
+		return nil, errors.Wrap(err, "Document.Marshal: failed to flush YAML encoder")
+	}
+	return buf.Bytes(), nil
+}
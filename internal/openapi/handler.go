@@ -0,0 +1,23 @@
+// file: internal/openapi/handler.go
+package openapi
+
+// handler.go serves a document over HTTP.
+
+import (
+	"net/http"
+
+	"github.com/cockroachdb/errors"
+)
+
+// Handler returns an http.Handler that serves the document as YAML, e.g. at /openapi.yaml.
+// The document is marshalled once, so later changes to doc are not reflected.
+func Handler(doc *Document) (http.Handler, error) {
+	body, err := doc.Marshal()
+	if err != nil {
+		return nil, errors.Wrap(err, "openapi.Handler: failed to marshal document")
+	}
+	return http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "application/yaml")
+		_, _ = w.Write(body)
+	}), nil
+}
@@ -0,0 +1,42 @@
+// Package openapitest provides helpers for asserting, in httptest-based tests, that
+// handler responses conform to the service's OpenAPI description.
+// file: internal/openapi/openapitest/openapitest.go
+package openapitest
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/dkoosis/hello-tool-base/internal/apperrors"
+	"github.com/dkoosis/hello-tool-base/internal/openapi"
+)
+
+// Check returns the violations of a recorded response against doc. It fails the test
+// if the document describes no operation for the request.
+func Check(t testing.TB, doc *openapi.Document, req *http.Request, rec *httptest.ResponseRecorder) []apperrors.Violation {
+	t.Helper()
+	violations, matched := doc.ValidateResponse(req.Method, req.URL.Path, rec.Code, rec.Header().Get("Content-Type"), rec.Body.Bytes())
+	if !matched {
+		t.Fatalf("openapitest: no OpenAPI operation matches %s %s", req.Method, req.URL.Path)
+	}
+	return violations
+}
+
+// AssertConforms reports a test error listing every violation if the recorded
+// response does not conform to doc. It returns true when the response conforms.
+func AssertConforms(t testing.TB, doc *openapi.Document, req *http.Request, rec *httptest.ResponseRecorder) bool {
+	t.Helper()
+	violations := Check(t, doc, req, rec)
+	if len(violations) == 0 {
+		return true
+	}
+	lines := make([]string, 0, len(violations))
+	for _, v := range violations {
+		lines = append(lines, "  "+v.Pointer+": "+v.Message)
+	}
+	t.Errorf("%s %s: response (status %d) does not conform to the OpenAPI schema:\n%s",
+		req.Method, req.URL.Path, rec.Code, strings.Join(lines, "\n"))
+	return false
+}
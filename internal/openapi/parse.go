@@ -0,0 +1,79 @@
+// file: internal/openapi/parse.go
+package openapi
+
+// parse.go loads documents and matches requests to their operations.
+
+import (
+	"strings"
+
+	"github.com/cockroachdb/errors"
+	"gopkg.in/yaml.v3"
+)
+
+// Parse decodes an OpenAPI document from YAML (or JSON, which is valid YAML).
+// Fields outside the supported subset are ignored.
+func Parse(data []byte) (*Document, error) {
+	var doc Document
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return nil, errors.Wrap(err, "openapi.Parse: failed to decode OpenAPI document")
+	}
+	if doc.Paths == nil {
+		return nil, errors.New("openapi.Parse: document has no paths")
+	}
+	return &doc, nil
+}
+
+// FindOperation returns the operation matching an HTTP method and request path, the
+// template it matched (e.g. /items/{id}) and the values of any path parameters.
+// Literal path segments take precedence over templated ones.
+func (d *Document) FindOperation(method, path string) (op *Operation, template string, params map[string]string) {
+	bestScore := -1
+	for tmpl, item := range d.Paths {
+		candidate := item.Operation(method)
+		if candidate == nil {
+			continue
+		}
+		values, score, ok := matchPath(tmpl, path)
+		if ok && score > bestScore {
+			op, template, params, bestScore = candidate, tmpl, values, score
+		}
+	}
+	return op, template, params
+}
+
+// matchPath matches a request path against a path template. The score counts literal
+// segments, so that /items/latest is preferred over /items/{id}.
+func matchPath(template, path string) (params map[string]string, score int, ok bool) {
+	tSegs := strings.Split(strings.Trim(template, "/"), "/")
+	pSegs := strings.Split(strings.Trim(path, "/"), "/")
+	if len(tSegs) != len(pSegs) {
+		return nil, 0, false
+	}
+	for i, seg := range tSegs {
+		if strings.HasPrefix(seg, "{") && strings.HasSuffix(seg, "}") {
+			if pSegs[i] == "" {
+				return nil, 0, false
+			}
+			if params == nil {
+				params = make(map[string]string)
+			}
+			params[seg[1:len(seg)-1]] = pSegs[i]
+			continue
+		}
+		if seg != pSegs[i] {
+			return nil, 0, false
+		}
+		score++
+	}
+	return params, score, true
+}
+
+// Pointer builds a JSON pointer (RFC 6901) from unescaped reference tokens.
+func Pointer(tokens ...string) string {
+	var b strings.Builder
+	for _, tok := range tokens {
+		b.WriteByte('/')
+		b.WriteString(strings.NewReplacer("~", "~0", "/", "~1").Replace(tok))
+	}
+	return b.String()
+}
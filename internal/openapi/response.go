@@ -0,0 +1,66 @@
+// file: internal/openapi/response.go
+package openapi
+
+// response.go checks responses against the documented response schemas.
+
+import (
+	"bytes"
+	"fmt"
+	"mime"
+	"strconv"
+
+	"github.com/dkoosis/hello-tool-base/internal/apperrors"
+)
+
+// ValidateResponse checks a response's status code and JSON body against the operation
+// matching method and path. Unlike request validation, properties the schema does not
+// declare are reported, since agents only know about documented fields.
+// matched is false when the document describes no operation for the request.
+func (d *Document) ValidateResponse(method, path string, status int, contentType string, body []byte) (violations []apperrors.Violation, matched bool) {
+	op, template, _ := d.FindOperation(method, path)
+	if op == nil {
+		return nil, false
+	}
+	v := &validator{doc: d, in: InResponse, rejectUnknown: true}
+
+	resp := op.response(status)
+	if resp == nil {
+		v.add(nil, fmt.Sprintf("has status %d, which is not documented for %s %s.", status, method, template))
+		return v.violations, true
+	}
+	if len(resp.Content) == 0 {
+		if len(bytes.TrimSpace(body)) > 0 {
+			v.add(nil, fmt.Sprintf("must be empty for status %d.", status))
+		}
+		return v.violations, true
+	}
+
+	mediaType, _, _ := mime.ParseMediaType(contentType)
+	media, ok := resp.Content[mediaType]
+	if !ok {
+		v.add(nil, fmt.Sprintf("has content type %q, which is not documented for status %d.", contentType, status))
+		return v.violations, true
+	}
+	if mediaType != "application/json" || media.Schema == nil {
+		return nil, true
+	}
+	value, err := DecodeJSON(body)
+	if err != nil {
+		v.add(nil, "must be valid JSON.")
+		return v.violations, true
+	}
+	v.validate(media.Schema, value, nil)
+	return v.violations, true
+}
+
+// response finds the documented response for a status code, falling back to a
+// range such as "2XX" and then to "default".
+func (op *Operation) response(status int) *Response {
+	if resp, ok := op.Responses[strconv.Itoa(status)]; ok {
+		return resp
+	}
+	if resp, ok := op.Responses[fmt.Sprintf("%dXX", status/100)]; ok {
+		return resp
+	}
+	return op.Responses["default"]
+}
@@ -0,0 +1,135 @@
+// file: internal/openapi/schema.go
+package openapi
+
+// schema.go defines the JSON Schema subset used by OpenAPI 3.0 and derives schemas from Go types.
+
+import (
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Schema is the subset of the OpenAPI 3.0 Schema Object supported by this service.
+// It marshals to both YAML (openapi.yaml) and JSON (e.g. MCP tool input schemas).
+type Schema struct {
+	Ref         string             `yaml:"$ref,omitempty" json:"$ref,omitempty"`
+	Type        string             `yaml:"type,omitempty" json:"type,omitempty"`
+	Format      string             `yaml:"format,omitempty" json:"format,omitempty"`
+	Description string             `yaml:"description,omitempty" json:"description,omitempty"`
+	Nullable    bool               `yaml:"nullable,omitempty" json:"nullable,omitempty"`
+	Enum        []string           `yaml:"enum,omitempty" json:"enum,omitempty"`
+	MinLength   *int               `yaml:"minLength,omitempty" json:"minLength,omitempty"`
+	MaxLength   *int               `yaml:"maxLength,omitempty" json:"maxLength,omitempty"`
+	MinItems    *int               `yaml:"minItems,omitempty" json:"minItems,omitempty"`
+	MaxItems    *int               `yaml:"maxItems,omitempty" json:"maxItems,omitempty"`
+	Minimum     *float64           `yaml:"minimum,omitempty" json:"minimum,omitempty"`
+	Maximum     *float64           `yaml:"maximum,omitempty" json:"maximum,omitempty"`
+	Pattern     string             `yaml:"pattern,omitempty" json:"pattern,omitempty"`
+	Items       *Schema            `yaml:"items,omitempty" json:"items,omitempty"`
+	Required    []string           `yaml:"required,omitempty" json:"required,omitempty"`
+	Properties  map[string]*Schema `yaml:"properties,omitempty" json:"properties,omitempty"`
+	Example     any                `yaml:"example,omitempty" json:"example,omitempty"`
+}
+
+// Ref returns a schema referencing a named component schema.
+func Ref(name string) *Schema {
+	return &Schema{Ref: "#/components/schemas/" + name}
+}
+
+var timeType = reflect.TypeFor[time.Time]()
+
+// SchemaFor derives a schema from a Go type.
+//
+// Struct fields are named by their json tag and are listed as required unless the tag
+// has omitempty. The `description`, `example` and `format` tags document a field.
+func SchemaFor(t reflect.Type) *Schema {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	if t == timeType {
+		return &Schema{Type: "string", Format: "date-time"}
+	}
+
+	switch t.Kind() {
+	case reflect.Struct:
+		s := &Schema{Type: "object", Properties: make(map[string]*Schema)}
+		for i := 0; i < t.NumField(); i++ {
+			sf := t.Field(i)
+			if !sf.IsExported() {
+				continue
+			}
+			name, opts, _ := strings.Cut(sf.Tag.Get("json"), ",")
+			if name == "-" {
+				continue
+			}
+			if name == "" {
+				name = sf.Name
+			}
+			prop := SchemaFor(sf.Type)
+			prop.Description = sf.Tag.Get("description")
+			if format := sf.Tag.Get("format"); format != "" {
+				prop.Format = format
+			}
+			if example, ok := sf.Tag.Lookup("example"); ok {
+				prop.Example = ExampleValue(sf.Type, example)
+			}
+			s.Properties[name] = prop
+			if !strings.Contains(opts, "omitempty") {
+				s.Required = append(s.Required, name)
+			}
+		}
+		return s
+	case reflect.Slice, reflect.Array:
+		return &Schema{Type: "array", Items: SchemaFor(t.Elem())}
+	case reflect.Map:
+		return &Schema{Type: "object"}
+	default:
+		return scalarSchema(t)
+	}
+}
+
+// scalarSchema maps Go scalar kinds to their JSON Schema type and format.
+func scalarSchema(t reflect.Type) *Schema {
+	switch t.Kind() {
+	case reflect.String:
+		return &Schema{Type: "string"}
+	case reflect.Bool:
+		return &Schema{Type: "boolean"}
+	case reflect.Int64, reflect.Uint64, reflect.Uint32:
+		return &Schema{Type: "integer", Format: "int64"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Uint, reflect.Uint8, reflect.Uint16:
+		return &Schema{Type: "integer", Format: "int32"}
+	case reflect.Float32:
+		return &Schema{Type: "number", Format: "float"}
+	case reflect.Float64:
+		return &Schema{Type: "number", Format: "double"}
+	default:
+		// Interfaces and other dynamic values accept any JSON value.
+		return &Schema{}
+	}
+}
+
+// ExampleValue converts an `example` tag to a value of the field's JSON type, so that
+// numeric and boolean examples are not emitted as strings.
+func ExampleValue(t reflect.Type, raw string) any {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	switch t.Kind() {
+	case reflect.Bool:
+		if b, err := strconv.ParseBool(raw); err == nil {
+			return b
+		}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		if n, err := strconv.ParseInt(raw, 10, 64); err == nil {
+			return n
+		}
+	case reflect.Float32, reflect.Float64:
+		if n, err := strconv.ParseFloat(raw, 64); err == nil {
+			return n
+		}
+	}
+	return raw
+}
@@ -0,0 +1,354 @@
+// file: internal/openapi/validate.go
+package openapi
+
+// validate.go checks decoded JSON values and HTTP requests against document schemas.
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"unicode/utf8"
+
+	"github.com/cockroachdb/errors"
+	"github.com/dkoosis/hello-tool-base/internal/apperrors"
+)
+
+// Parameter and body locations, used as the "in" value of violations.
+const (
+	InPath   = "path"
+	InQuery  = "query"
+	InHeader = "header"
+	InBody   = "body"
+	// InResponse marks violations found in a response body rather than a request.
+	InResponse = "response"
+)
+
+// typeProblem is the violation message for a value of the wrong JSON type.
+var typeProblem = map[string]string{
+	"integer": "must be an integer.",
+	"number":  "must be a number.",
+}
+
+// patternCache holds compiled schema patterns, keyed by their source.
+var patternCache sync.Map
+
+// validator walks a decoded JSON value and collects every violation it finds.
+type validator struct {
+	doc *Document
+	in  string
+	// rejectUnknown reports object properties the schema does not declare.
+	rejectUnknown bool
+	violations    []apperrors.Violation
+}
+
+// ValidateValue checks a value decoded from JSON (numbers as json.Number) against s,
+// resolving $ref against the document's components. in and name identify the value in
+// violation messages; violation pointers are rooted at /in/name (or /body for bodies).
+// When rejectUnknown is set, properties not declared by an object schema are violations.
+func (d *Document) ValidateValue(s *Schema, value any, in, name string, rejectUnknown bool) []apperrors.Violation {
+	v := &validator{doc: d, in: in, rejectUnknown: rejectUnknown}
+	var path []string
+	if name != "" {
+		path = []string{name}
+	}
+	v.validate(s, value, path)
+	return v.violations
+}
+
+// resolve follows a $ref to a component schema. Unresolvable references yield nil,
+// which leaves the value unconstrained.
+func (d *Document) resolve(s *Schema) *Schema {
+	for s != nil && s.Ref != "" {
+		name, ok := strings.CutPrefix(s.Ref, "#/components/schemas/")
+		if !ok || d.Components == nil {
+			return nil
+		}
+		s = d.Components.Schemas[name]
+	}
+	return s
+}
+
+// add records a violation for the value at path.
+func (v *validator) add(path []string, problem string) {
+	field := strings.Join(path, ".")
+	var subject string
+	switch {
+	case v.in == InBody && field == "":
+		subject, field = "The request body", "body"
+	case v.in == InBody:
+		subject = fmt.Sprintf("The '%s' body field", field)
+	case v.in == InResponse && field == "":
+		subject, field = "The response body", "body"
+	case v.in == InResponse:
+		subject = fmt.Sprintf("The '%s' response field", field)
+	default:
+		subject = fmt.Sprintf("The '%s' %s parameter", field, v.in)
+	}
+	v.violations = append(v.violations, apperrors.Violation{
+		Field:   field,
+		In:      v.in,
+		Pointer: Pointer(append([]string{v.in}, path...)...),
+		Message: subject + " " + problem,
+	})
+}
+
+// validate checks value against s, recursing into arrays and objects.
+func (v *validator) validate(s *Schema, value any, path []string) {
+	s = v.doc.resolve(s)
+	if s == nil {
+		return
+	}
+	if value == nil {
+		if !s.Nullable && s.Type != "" {
+			v.add(path, "must not be null.")
+		}
+		return
+	}
+
+	switch s.Type {
+	case "string":
+		str, ok := value.(string)
+		if !ok {
+			v.add(path, "must be a string.")
+			return
+		}
+		v.checkString(s, str, path)
+	case "integer", "number":
+		num, ok := value.(json.Number)
+		if !ok {
+			v.add(path, typeProblem[s.Type])
+			return
+		}
+		v.checkNumber(s, num, path)
+	case "boolean":
+		if _, ok := value.(bool); !ok {
+			v.add(path, "must be a boolean.")
+		}
+	case "array":
+		items, ok := value.([]any)
+		if !ok {
+			v.add(path, "must be an array.")
+			return
+		}
+		if s.MinItems != nil && len(items) < *s.MinItems {
+			v.add(path, fmt.Sprintf("must contain at least %d items.", *s.MinItems))
+		}
+		if s.MaxItems != nil && len(items) > *s.MaxItems {
+			v.add(path, fmt.Sprintf("must contain at most %d items.", *s.MaxItems))
+		}
+		for i, item := range items {
+			v.validate(s.Items, item, append(path[:len(path):len(path)], strconv.Itoa(i)))
+		}
+	case "object":
+		obj, ok := value.(map[string]any)
+		if !ok {
+			v.add(path, "must be an object.")
+			return
+		}
+		v.checkObject(s, obj, path)
+	}
+
+	if len(s.Enum) > 0 && s.Type != "string" && !contains(s.Enum, fmt.Sprint(value)) {
+		v.add(path, fmt.Sprintf("must be one of: %s.", strings.Join(s.Enum, ", ")))
+	}
+}
+
+// checkString applies length, pattern and enum constraints.
+func (v *validator) checkString(s *Schema, str string, path []string) {
+	n := utf8.RuneCountInString(str)
+	if s.MinLength != nil && n < *s.MinLength {
+		v.add(path, fmt.Sprintf("must be at least %d characters long.", *s.MinLength))
+	}
+	if s.MaxLength != nil && n > *s.MaxLength {
+		v.add(path, fmt.Sprintf("must be at most %d characters long.", *s.MaxLength))
+	}
+	if s.Pattern != "" {
+		if re := compilePattern(s.Pattern); re != nil && !re.MatchString(str) {
+			v.add(path, fmt.Sprintf("must match the pattern %s.", s.Pattern))
+		}
+	}
+	if len(s.Enum) > 0 && !contains(s.Enum, str) {
+		v.add(path, fmt.Sprintf("must be one of: %s.", strings.Join(s.Enum, ", ")))
+	}
+}
+
+// checkNumber applies integer and range constraints.
+func (v *validator) checkNumber(s *Schema, num json.Number, path []string) {
+	f, err := num.Float64()
+	if err != nil || (s.Type == "integer" && f != float64(int64(f))) {
+		v.add(path, typeProblem[s.Type])
+		return
+	}
+	if s.Minimum != nil && f < *s.Minimum {
+		v.add(path, fmt.Sprintf("must be at least %v.", *s.Minimum))
+	}
+	if s.Maximum != nil && f > *s.Maximum {
+		v.add(path, fmt.Sprintf("must be at most %v.", *s.Maximum))
+	}
+}
+
+// checkObject applies required and property constraints.
+func (v *validator) checkObject(s *Schema, obj map[string]any, path []string) {
+	for _, name := range s.Required {
+		if _, ok := obj[name]; !ok {
+			v.add(append(path[:len(path):len(path)], name), "is required.")
+		}
+	}
+	names := make([]string, 0, len(obj))
+	for name := range obj {
+		names = append(names, name)
+	}
+	sort.Strings(names) // Report violations in a stable order.
+	for _, name := range names {
+		value := obj[name]
+		prop, declared := s.Properties[name]
+		if !declared {
+			if v.rejectUnknown && s.Properties != nil {
+				v.add(append(path[:len(path):len(path)], name), "is not declared by the schema.")
+			}
+			continue
+		}
+		v.validate(prop, value, append(path[:len(path):len(path)], name))
+	}
+}
+
+// ValidateRequest validates r's path, query and header parameters and its JSON body
+// against the matching operation. matched is false when the document describes no
+// operation for the request. The body is restored, so handlers can still read it.
+func (d *Document) ValidateRequest(r *http.Request) (violations []apperrors.Violation, matched bool, err error) {
+	op, _, pathParams := d.FindOperation(r.Method, r.URL.Path)
+	if op == nil {
+		return nil, false, nil
+	}
+
+	query := r.URL.Query()
+	for _, p := range op.Parameters {
+		var raw []string
+		switch p.In {
+		case InPath:
+			if value, ok := pathParams[p.Name]; ok {
+				raw = []string{value}
+			}
+		case InQuery:
+			raw = query[p.Name]
+		case InHeader:
+			raw = r.Header.Values(p.Name)
+		default:
+			continue // Cookie parameters are not validated.
+		}
+		v := &validator{doc: d, in: p.In}
+		if len(raw) == 0 {
+			if p.Required {
+				v.add([]string{p.Name}, "is required.")
+			}
+		} else {
+			v.validate(p.Schema, coerceParam(d.resolve(p.Schema), raw), []string{p.Name})
+		}
+		violations = append(violations, v.violations...)
+	}
+
+	if op.RequestBody == nil {
+		return violations, true, nil
+	}
+	var body []byte
+	if r.Body != nil {
+		body, err = io.ReadAll(r.Body)
+		if err != nil {
+			return nil, true, errors.Wrap(err, "Document.ValidateRequest: failed to read request body")
+		}
+		r.Body = io.NopCloser(bytes.NewReader(body))
+	}
+	v := &validator{doc: d, in: InBody}
+	media := op.RequestBody.Content["application/json"]
+	switch {
+	case len(bytes.TrimSpace(body)) == 0:
+		if op.RequestBody.Required {
+			v.add(nil, "is required.")
+		}
+	case media != nil:
+		value, decodeErr := DecodeJSON(body)
+		if decodeErr != nil {
+			v.add(nil, "must be valid JSON.")
+		} else {
+			v.validate(media.Schema, value, nil)
+		}
+	}
+	return append(violations, v.violations...), true, nil
+}
+
+// DecodeJSON decodes a JSON document into generic values, keeping numbers as
+// json.Number so integers and floats can be told apart during validation.
+func DecodeJSON(data []byte) (any, error) {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	var value any
+	if err := dec.Decode(&value); err != nil {
+		return nil, errors.Wrap(err, "openapi.DecodeJSON: invalid JSON")
+	}
+	if dec.More() {
+		return nil, errors.New("openapi.DecodeJSON: unexpected data after JSON value")
+	}
+	return value, nil
+}
+
+// coerceParam converts raw string parameter values into the JSON types the schema
+// expects, so they can be validated like body values. Values that do not parse are
+// left as strings and then fail the schema's type check.
+func coerceParam(s *Schema, raw []string) any {
+	if s != nil && s.Type == "array" {
+		items := make([]any, len(raw))
+		for i, r := range raw {
+			items[i] = coerceScalar(s.Items, r)
+		}
+		return items
+	}
+	return coerceScalar(s, raw[0])
+}
+
+// coerceScalar converts a single raw parameter value.
+func coerceScalar(s *Schema, raw string) any {
+	if s == nil {
+		return raw
+	}
+	switch s.Type {
+	case "integer", "number":
+		if _, err := strconv.ParseFloat(raw, 64); err == nil {
+			return json.Number(raw)
+		}
+	case "boolean":
+		if b, err := strconv.ParseBool(raw); err == nil {
+			return b
+		}
+	}
+	return raw
+}
+
+// compilePattern compiles and caches a schema pattern. Invalid patterns are ignored.
+func compilePattern(pattern string) *regexp.Regexp {
+	if re, ok := patternCache.Load(pattern); ok {
+		return re.(*regexp.Regexp)
+	}
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		return nil
+	}
+	patternCache.Store(pattern, re)
+	return re
+}
+
+// contains reports whether s is one of values.
+func contains(values []string, s string) bool {
+	for _, v := range values {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,87 @@
+// file: internal/openapi/validate_test.go
+package openapi
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testSpec = `
+openapi: 3.0.3
+info: {title: Test, version: "1"}
+paths:
+  /items/{id}:
+    post:
+      operationId: updateItem
+      parameters:
+        - {name: id, in: path, required: true, schema: {type: integer, minimum: 1}}
+        - {name: mode, in: query, schema: {type: string, enum: [fast, safe]}}
+        - {name: X-Tenant, in: header, required: true, schema: {type: string}}
+      requestBody:
+        required: true
+        content:
+          application/json:
+            schema: {$ref: '#/components/schemas/Item'}
+      responses:
+        "200": {description: OK}
+components:
+  schemas:
+    Item:
+      type: object
+      required: [name]
+      properties:
+        name: {type: string, maxLength: 5}
+        tags:
+          type: array
+          items: {type: string, pattern: '^[a-z]+$'}
+`
+
+func pointers(t *testing.T, r *http.Request) []string {
+	t.Helper()
+	doc, err := Parse([]byte(testSpec))
+	require.NoError(t, err)
+	violations, matched, err := doc.ValidateRequest(r)
+	require.NoError(t, err)
+	require.True(t, matched)
+	out := make([]string, 0, len(violations))
+	for _, v := range violations {
+		out = append(out, v.Pointer)
+	}
+	return out
+}
+
+// TestValidateRequest_ReportsNoViolations_When_RequestConforms (ADR-008 Naming)
+func TestValidateRequest_ReportsNoViolations_When_RequestConforms(t *testing.T) {
+	r := httptest.NewRequest(http.MethodPost, "/items/7?mode=safe", strings.NewReader(`{"name":"pen","tags":["blue"]}`))
+	r.Header.Set("X-Tenant", "acme")
+	assert.Empty(t, pointers(t, r))
+}
+
+// TestValidateRequest_ReportsEveryPointer_When_AllLocationsAreInvalid (ADR-008 Naming)
+func TestValidateRequest_ReportsEveryPointer_When_AllLocationsAreInvalid(t *testing.T) {
+	r := httptest.NewRequest(http.MethodPost, "/items/0?mode=turbo", strings.NewReader(`{"name":"notebook","tags":["ok","Bad"]}`))
+	assert.ElementsMatch(t, []string{
+		"/path/id", "/query/mode", "/header/X-Tenant", "/body/name", "/body/tags/1",
+	}, pointers(t, r))
+}
+
+// TestValidateRequest_ReportsTypeError_When_PathParameterIsNotAnInteger (ADR-008 Naming)
+func TestValidateRequest_ReportsTypeError_When_PathParameterIsNotAnInteger(t *testing.T) {
+	r := httptest.NewRequest(http.MethodPost, "/items/abc", strings.NewReader(`{"name":"pen"}`))
+	r.Header.Set("X-Tenant", "acme")
+	assert.Equal(t, []string{"/path/id"}, pointers(t, r))
+}
+
+// TestValidateRequest_SkipsValidation_When_NoOperationMatches (ADR-008 Naming)
+func TestValidateRequest_SkipsValidation_When_NoOperationMatches(t *testing.T) {
+	doc, err := Parse([]byte(testSpec))
+	require.NoError(t, err)
+	_, matched, err := doc.ValidateRequest(httptest.NewRequest(http.MethodGet, "/items/1", nil))
+	require.NoError(t, err)
+	assert.False(t, matched)
+}
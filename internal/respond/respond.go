@@ -0,0 +1,125 @@
+// Package respond provides helpers for writing JSON responses and the standard
+// JSON error body shared by every HTTP endpoint and middleware in the service.
+// Application errors from internal/apperrors are mapped to HTTP status codes and
+// client-safe messages here, so handlers only need to return errors.
+// file: internal/respond/respond.go
+package respond
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/cockroachdb/errors"
+	"github.com/dkoosis/hello-tool-base/internal/apperrors"
+	"github.com/dkoosis/hello-tool-base/internal/logging"
+)
+
+// ErrorResponse defines the structure for a JSON error message to the client.
+// Its schema is published in openapi.yaml as the ErrorResponse component.
+type ErrorResponse struct {
+	Error      string                `json:"error" description:"A user-facing error message." example:"Invalid Request Parameter"`
+	Details    string                `json:"details,omitempty" description:"Optional additional details about the error."`
+	Code       int                   `json:"code" description:"A service-specific error code, aligned with the JSON-RPC error codes." example:"-32602"`
+	Violations []apperrors.Violation `json:"violations,omitempty" description:"Every input constraint the request failed, when the error is a validation failure."`
+}
+
+// JSON writes payload as a JSON response with the given status code.
+// Encoding failures are logged with the provided (ideally request-scoped) logger.
+func JSON(l logging.Logger, w http.ResponseWriter, statusCode int, payload interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(statusCode)
+	if err := json.NewEncoder(w).Encode(payload); err != nil {
+		wrappedErr := errors.Wrap(err, "respond.JSON: failed to encode JSON response payload")
+		l.Error("Failed to encode JSON response", "error", fmt.Sprintf("%+v", wrappedErr))
+	}
+}
+
+// Error logs err server-side and writes the standard JSON error body.
+// The HTTP status and client message are derived from the application error code;
+// internal details of server-side failures are never sent to the client.
+func Error(l logging.Logger, w http.ResponseWriter, err error) {
+	statusCode := HTTPStatus(err)
+	body := NewErrorResponse(err)
+	l.Error("Server-side error occurred",
+		"internal_error", fmt.Sprintf("%+v", err),
+		"client_message", body.Error,
+		"client_details", body.Details,
+		"http_status_code", statusCode,
+	)
+	JSON(l, w, statusCode, body)
+}
+
+// NewErrorResponse builds the client-facing error body for err.
+func NewErrorResponse(err error) ErrorResponse {
+	statusCode := HTTPStatus(err)
+	code, _, _ := apperrors.MapAppErrorToJSONRPC(err)
+	resp := ErrorResponse{Error: clientMessage(statusCode), Code: code}
+
+	if violations := apperrors.ViolationsFrom(err); len(violations) > 0 {
+		messages := make([]string, 0, len(violations))
+		for _, v := range violations {
+			messages = append(messages, v.Message)
+		}
+		resp.Details = strings.Join(messages, " ")
+		resp.Violations = violations
+		return resp
+	}
+
+	// Only client errors carry their message through; 5xx details stay in the server logs.
+	if baseErr, ok := apperrors.AsBaseError(err); ok && statusCode < http.StatusInternalServerError {
+		resp.Details = baseErr.Message
+	}
+	return resp
+}
+
+// HTTPStatus maps an error to the HTTP status code that best describes it.
+// Errors that are not application errors map to 500 Internal Server Error.
+func HTTPStatus(err error) int {
+	baseErr, ok := apperrors.AsBaseError(err)
+	if !ok {
+		return http.StatusInternalServerError
+	}
+	switch baseErr.Code {
+	case apperrors.ErrInvalidParams, apperrors.ErrParseError, apperrors.ErrInvalidRequest,
+		apperrors.ErrProtocolInvalid, apperrors.ErrResourceInvalid:
+		return http.StatusBadRequest
+	case apperrors.ErrAuthFailure, apperrors.ErrAuthExpired, apperrors.ErrAuthInvalid, apperrors.ErrAuthMissing:
+		return http.StatusUnauthorized
+	case apperrors.ErrResourceForbidden:
+		return http.StatusForbidden
+	case apperrors.ErrResourceNotFound, apperrors.ErrMethodNotFound:
+		return http.StatusNotFound
+	case apperrors.ErrRequestSequence:
+		return http.StatusConflict
+	case apperrors.ErrProtocolUnsupported:
+		return http.StatusNotImplemented
+	case apperrors.ErrServiceNotFound:
+		return http.StatusServiceUnavailable
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+// clientMessage returns the short, user-facing summary for a status code.
+func clientMessage(statusCode int) string {
+	switch statusCode {
+	case http.StatusBadRequest:
+		return "Invalid Request Parameter"
+	case http.StatusUnauthorized:
+		return "Authentication Required"
+	case http.StatusForbidden:
+		return "Access Forbidden"
+	case http.StatusNotFound:
+		return "Not Found"
+	case http.StatusConflict:
+		return "Request Out Of Sequence"
+	case http.StatusNotImplemented:
+		return "Not Implemented"
+	case http.StatusServiceUnavailable:
+		return "Service Unavailable"
+	default:
+		return "Internal Server Error"
+	}
+}
@@ -0,0 +1,220 @@
+// Package server owns the HTTP lifecycle shared by every tool binary: route
+// registration, middleware chaining, http.Server timeouts and graceful shutdown
+// on SIGINT/SIGTERM. Tools build a Server from their configuration, register
+// their handlers and call Run; errors are returned to the caller instead of
+// terminating the process.
+// file: internal/server/server.go
+package server
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/cockroachdb/errors"
+	"github.com/dkoosis/hello-tool-base/internal/config"
+	"github.com/dkoosis/hello-tool-base/internal/logging"
+	"github.com/dkoosis/hello-tool-base/internal/middleware"
+)
+
+// Middleware wraps an http.Handler to add cross-cutting behaviour.
+type Middleware func(http.Handler) http.Handler
+
+// Server bundles the configuration, logger, routes and middleware of a tool service.
+// Create one with New; it is not safe to register routes after Run has been called.
+type Server struct {
+	cfg *config.Config
+	// current returns the active configuration; see WithConfigSource.
+	current    func() *config.Config
+	logger     logging.Logger
+	mux        *http.ServeMux
+	middleware []Middleware
+	listener   net.Listener
+	signals    []os.Signal
+}
+
+// Option configures a Server during construction.
+type Option func(*Server)
+
+// WithLogger sets the application logger. It is used for lifecycle messages and
+// as the base logger for the request-scoped loggers created by the tracing middleware.
+func WithLogger(logger logging.Logger) Option {
+	return func(s *Server) {
+		if logger != nil {
+			s.logger = logger
+		}
+	}
+}
+
+// WithMiddleware appends middleware to the chain. Middleware runs in the order
+// given, after the built-in tracing middleware, so the request-scoped logger is
+// already available in the request context.
+func WithMiddleware(mw ...Middleware) Option {
+	return func(s *Server) {
+		s.middleware = append(s.middleware, mw...)
+	}
+}
+
+// WithHandler registers a handler for the given ServeMux pattern.
+func WithHandler(pattern string, handler http.Handler) Option {
+	return func(s *Server) {
+		s.mux.Handle(pattern, handler)
+	}
+}
+
+// WithListener makes Run serve on an existing listener instead of binding the
+// configured port. This is primarily useful in tests that need an ephemeral port.
+func WithListener(l net.Listener) Option {
+	return func(s *Server) {
+		s.listener = l
+	}
+}
+
+// WithSignals overrides the OS signals that trigger a graceful shutdown.
+// Passing no signals disables signal handling; Run then stops only when its context is cancelled.
+func WithSignals(sigs ...os.Signal) Option {
+	return func(s *Server) {
+		s.signals = sigs
+	}
+}
+
+// WithConfigSource makes the server read its read, write and graceful timeouts from
+// current, e.g. config.Store.Config, when it uses them, so a reloaded configuration
+// applies without a restart. The read and write timeouts then apply to each request
+// from the time its handler starts. The port and the idle timeout are always those of
+// the configuration passed to New.
+func WithConfigSource(current func() *config.Config) Option {
+	return func(s *Server) {
+		if current != nil {
+			s.current = current
+		}
+	}
+}
+
+// New creates a Server for the given configuration.
+// It returns an error if the configuration is nil.
+func New(cfg *config.Config, opts ...Option) (*Server, error) {
+	if cfg == nil {
+		return nil, errors.New("server.New: configuration must not be nil")
+	}
+	s := &Server{
+		cfg:     cfg,
+		current: func() *config.Config { return cfg },
+		logger:  logging.GetLogger("server"),
+		mux:     http.NewServeMux(),
+		signals: []os.Signal{syscall.SIGINT, syscall.SIGTERM},
+	}
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s, nil
+}
+
+// Handle registers a handler for the given ServeMux pattern.
+func (s *Server) Handle(pattern string, handler http.Handler) {
+	s.mux.Handle(pattern, handler)
+}
+
+// HandleFunc registers a handler function for the given ServeMux pattern.
+func (s *Server) HandleFunc(pattern string, handler func(http.ResponseWriter, *http.Request)) {
+	s.mux.HandleFunc(pattern, handler)
+}
+
+// Use appends middleware to the chain. See WithMiddleware for ordering.
+func (s *Server) Use(mw ...Middleware) {
+	s.middleware = append(s.middleware, mw...)
+}
+
+// Config returns the configuration the server was created with.
+func (s *Server) Config() *config.Config {
+	return s.cfg
+}
+
+// Logger returns the application logger used by the server.
+func (s *Server) Logger() logging.Logger {
+	return s.logger
+}
+
+// Handler returns the fully wrapped HTTP handler: the request deadlines first, then
+// tracing, then any registered middleware, then the route multiplexer. It allows the
+// complete stack to be exercised with httptest without binding a port.
+func (s *Server) Handler() http.Handler {
+	var h http.Handler = s.mux
+	for i := len(s.middleware) - 1; i >= 0; i-- {
+		h = s.middleware[i](h)
+	}
+	return s.deadlines(middleware.Tracing(s.logger)(h))
+}
+
+// deadlines sets the read and write deadlines of each request's connection from the
+// active configuration's timeouts, replacing those http.Server set from the startup
+// configuration. Writers that do not support deadlines, such as httptest recorders,
+// are left as they are.
+func (s *Server) deadlines(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		cfg := s.current().Server
+		now := time.Now()
+		rc := http.NewResponseController(w)
+		_ = rc.SetReadDeadline(now.Add(cfg.ReadTimeout))
+		_ = rc.SetWriteDeadline(now.Add(cfg.WriteTimeout))
+		next.ServeHTTP(w, r)
+	})
+}
+
+// Run starts serving and blocks until ctx is cancelled, one of the configured
+// shutdown signals is received, or the listener fails. On shutdown it waits up to
+// the configured graceful timeout for in-flight requests to complete.
+// A clean shutdown returns nil.
+func (s *Server) Run(ctx context.Context) error {
+	if len(s.signals) > 0 {
+		var stop context.CancelFunc
+		ctx, stop = signal.NotifyContext(ctx, s.signals...)
+		defer stop()
+	}
+
+	srv := &http.Server{
+		Addr:         fmt.Sprintf(":%d", s.cfg.Server.Port),
+		Handler:      s.Handler(),
+		ReadTimeout:  s.cfg.Server.ReadTimeout,
+		WriteTimeout: s.cfg.Server.WriteTimeout,
+		IdleTimeout:  s.cfg.Server.IdleTimeout,
+	}
+
+	listener := s.listener
+	if listener == nil {
+		var err error
+		listener, err = net.Listen("tcp", srv.Addr)
+		if err != nil {
+			return errors.Wrapf(err, "server.Run: failed to listen on %s", srv.Addr)
+		}
+	}
+
+	serveErr := make(chan error, 1)
+	go func() {
+		s.logger.Info("Server listening", "address", listener.Addr().String())
+		serveErr <- srv.Serve(listener)
+	}()
+
+	select {
+	case err := <-serveErr:
+		if err != nil && !errors.Is(err, http.ErrServerClosed) {
+			return errors.Wrap(err, "server.Run: server failed while serving")
+		}
+		return nil
+	case <-ctx.Done():
+		s.logger.Info("Shutdown requested, draining connections...", "reason", context.Cause(ctx).Error())
+	}
+
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), s.current().Server.GracefulTimeout)
+	defer cancel()
+	if err := srv.Shutdown(shutdownCtx); err != nil {
+		return errors.Wrap(err, "server.Run: graceful shutdown failed")
+	}
+	s.logger.Info("Server exited gracefully.")
+	return nil
+}
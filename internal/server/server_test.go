@@ -0,0 +1,109 @@
+// file: internal/server/server_test.go
+package server
+
+import (
+	"context"
+	"io"
+	"net"
+	"net/http"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dkoosis/hello-tool-base/internal/config"
+	"github.com/dkoosis/hello-tool-base/internal/logging"
+	"github.com/dkoosis/hello-tool-base/internal/middleware"
+)
+
+// TestServer_ReturnsError_When_ConfigIsNil (ADR-008 Naming)
+func TestServer_ReturnsError_When_ConfigIsNil(t *testing.T) {
+	_, err := New(nil)
+	require.Error(t, err)
+}
+
+// TestServer_ServesRoutesAndShutsDown_When_ContextCancelled (ADR-008 Naming)
+func TestServer_ServesRoutesAndShutsDown_When_ContextCancelled(t *testing.T) {
+	// Arrange
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+
+	var sawTraceID bool
+	srv, err := New(config.DefaultConfig(),
+		WithLogger(logging.GetNoopLogger()),
+		WithListener(listener),
+		WithSignals(),
+		WithHandler("/ping", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			sawTraceID = middleware.GetTraceIDFromContext(r.Context()) != ""
+			_, _ = io.WriteString(w, "pong")
+		})),
+	)
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+	go func() { done <- srv.Run(ctx) }()
+
+	// Act
+	resp, err := http.Get("http://" + listener.Addr().String() + "/ping")
+	require.NoError(t, err)
+	body, _ := io.ReadAll(resp.Body)
+	_ = resp.Body.Close()
+	cancel()
+
+	// Assert
+	assert.Equal(t, "pong", string(body))
+	assert.True(t, sawTraceID, "tracing middleware should run before registered handlers")
+	select {
+	case err := <-done:
+		assert.NoError(t, err)
+	case <-time.After(5 * time.Second):
+		t.Fatal("Run did not return after context cancellation")
+	}
+}
+
+// TestServer_AppliesReloadedWriteTimeout_When_ConfigSourceChanges (ADR-008 Naming)
+func TestServer_AppliesReloadedWriteTimeout_When_ConfigSourceChanges(t *testing.T) {
+	// Arrange
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	var active atomic.Pointer[config.Config]
+	active.Store(config.DefaultConfig())
+	srv, err := New(config.DefaultConfig(),
+		WithLogger(logging.GetNoopLogger()),
+		WithListener(listener),
+		WithSignals(),
+		WithConfigSource(active.Load),
+		WithHandler("/slow", http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			time.Sleep(200 * time.Millisecond)
+			_, _ = io.WriteString(w, "done")
+		})),
+	)
+	require.NoError(t, err)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go func() { _ = srv.Run(ctx) }()
+	url := "http://" + listener.Addr().String() + "/slow"
+	get := func() error {
+		resp, err := http.Get(url)
+		if err != nil {
+			return err
+		}
+		defer func() { _ = resp.Body.Close() }()
+		_, err = io.ReadAll(resp.Body)
+		return err
+	}
+
+	// Act
+	before := get()
+	reloaded := config.DefaultConfig()
+	reloaded.Server.WriteTimeout = 50 * time.Millisecond
+	active.Store(reloaded)
+	after := get()
+
+	// Assert
+	assert.NoError(t, before)
+	assert.Error(t, after, "the reloaded write timeout must cut the slow response off")
+}
@@ -0,0 +1,106 @@
+// file: internal/tools/call.go
+package tools
+
+// call.go invokes tools with JSON arguments, for protocols other than REST (e.g. MCP).
+
+import (
+	"context"
+	"encoding/json"
+	"reflect"
+
+	"github.com/dkoosis/hello-tool-base/internal/apperrors"
+	"github.com/dkoosis/hello-tool-base/internal/openapi"
+)
+
+// InputSchema returns a JSON Schema object describing the tool's arguments when it is
+// called with Call. Parameters and body fields are flattened into one object keyed by
+// their external names, with the same constraints as the REST operation.
+func (t *Tool) InputSchema() *openapi.Schema {
+	s := &openapi.Schema{Type: "object", Properties: make(map[string]*openapi.Schema)}
+	for _, f := range t.fields {
+		prop := f.schema()
+		prop.Description = f.Description
+		s.Properties[f.Name] = prop
+		if f.Required {
+			s.Required = append(s.Required, f.Name)
+		}
+	}
+	return s
+}
+
+// OutputSchema returns the JSON Schema of the tool's successful result.
+func (t *Tool) OutputSchema() *openapi.Schema {
+	return openapi.SchemaFor(t.respType)
+}
+
+// Call binds a JSON object of arguments, validates it exactly like an HTTP request
+// and invokes the handler. args may be empty when the tool takes no inputs.
+// Validation failures are returned as an apperrors validation error listing every violation.
+func (t *Tool) Call(ctx context.Context, args json.RawMessage) (any, error) {
+	ctx = ContextWithTool(ctx, t.Name)
+
+	req, err := t.bindArgs(args)
+	if err != nil {
+		return nil, err
+	}
+	return t.invoke(ctx, req)
+}
+
+// bindArgs populates a new request value from a JSON object of arguments.
+func (t *Tool) bindArgs(args json.RawMessage) (reflect.Value, error) {
+	req := reflect.New(t.reqType).Elem()
+	errContext := map[string]interface{}{"toolName": t.Name}
+
+	var values map[string]json.RawMessage
+	if len(args) > 0 && string(args) != "null" {
+		if err := json.Unmarshal(args, &values); err != nil {
+			return req, apperrors.NewValidationError("tools: arguments must be a JSON object for "+t.Name, []apperrors.Violation{{
+				Field: "arguments", In: InBody, Pointer: openapi.Pointer(), Message: "The arguments must be a JSON object.",
+			}}, errContext)
+		}
+	}
+
+	var violations []apperrors.Violation
+	for _, f := range t.fields {
+		raw, present := values[f.Name]
+		present = present && string(raw) != "null"
+		v := req.FieldByIndex(f.index)
+		pointer := openapi.Pointer(f.Name)
+
+		if present {
+			if err := setFromJSON(v, raw); err != nil {
+				violations = append(violations, apperrors.Violation{
+					Field: f.Name, In: f.In, Pointer: pointer, Message: f.describe() + " has the wrong type: " + err.Error() + ".",
+				})
+				continue
+			}
+		}
+		for _, problem := range f.validate(v, present) {
+			violations = append(violations, apperrors.Violation{
+				Field: f.Name, In: f.In, Pointer: pointer, Message: f.describe() + " " + problem,
+			})
+		}
+	}
+
+	if len(violations) > 0 {
+		return req, apperrors.NewValidationError("tools: argument validation failed for "+t.Name, violations, errContext)
+	}
+	return req, nil
+}
+
+// setFromJSON decodes a JSON argument into v. A JSON string is also accepted for
+// scalar parameters of other types (e.g. "5" for an integer), mirroring how the same
+// parameter would arrive in a query string.
+func setFromJSON(v reflect.Value, raw json.RawMessage) error {
+	err := json.Unmarshal(raw, v.Addr().Interface())
+	if err == nil {
+		return nil
+	}
+	var s string
+	if isScalarOrSlice(v.Type()) && json.Unmarshal(raw, &s) == nil {
+		if strErr := setFromStrings(v, []string{s}); strErr == nil {
+			return nil
+		}
+	}
+	return err
+}
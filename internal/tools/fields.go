@@ -0,0 +1,297 @@
+// file: internal/tools/fields.go
+package tools
+
+// fields.go derives binding and validation metadata from request struct tags.
+
+import (
+	"fmt"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/cockroachdb/errors"
+)
+
+// Parameter sources, used as the "in" value of a field and of any violation reported against it.
+const (
+	InQuery  = "query"
+	InHeader = "header"
+	InPath   = "path"
+	InBody   = "body"
+)
+
+// Field describes one bindable input of a tool's request struct.
+// It is derived from the struct tags documented on New.
+type Field struct {
+	Name        string // External name: the query/header/path parameter or JSON property name.
+	In          string // One of InQuery, InHeader, InPath or InBody.
+	Type        reflect.Type
+	Required    bool
+	MinLength   *int
+	MaxLength   *int
+	Minimum     *float64
+	Maximum     *float64
+	Enum        []string
+	Pattern     *regexp.Regexp
+	Description string
+	Example     string
+
+	index []int
+}
+
+// parseFields walks the exported fields of a request struct type and returns their metadata.
+// A field bound from the query, header or path takes its name from that tag; any other field
+// with a json tag is read from the JSON request body. Fields with neither are ignored.
+func parseFields(t reflect.Type) ([]Field, error) {
+	if t.Kind() != reflect.Struct {
+		return nil, errors.Newf("parseFields: request type %s must be a struct", t)
+	}
+
+	var fields []Field
+	for i := 0; i < t.NumField(); i++ {
+		sf := t.Field(i)
+		if !sf.IsExported() {
+			continue
+		}
+
+		f := Field{
+			Type:        sf.Type,
+			Description: sf.Tag.Get("description"),
+			Example:     sf.Tag.Get("example"),
+			index:       sf.Index,
+		}
+		switch {
+		case sf.Tag.Get(InQuery) != "":
+			f.Name, f.In = sf.Tag.Get(InQuery), InQuery
+		case sf.Tag.Get(InHeader) != "":
+			f.Name, f.In = sf.Tag.Get(InHeader), InHeader
+		case sf.Tag.Get(InPath) != "":
+			f.Name, f.In = sf.Tag.Get(InPath), InPath
+		default:
+			name, _, _ := strings.Cut(sf.Tag.Get("json"), ",")
+			if name == "" || name == "-" {
+				continue
+			}
+			f.Name, f.In = name, InBody
+		}
+
+		if f.In != InBody && !isScalarOrSlice(sf.Type) {
+			return nil, errors.Newf("parseFields: field %s of %s has unsupported type %s for a %s parameter", sf.Name, t, sf.Type, f.In)
+		}
+		if err := parseValidateTag(&f, sf.Tag.Get("validate")); err != nil {
+			return nil, errors.Wrapf(err, "parseFields: invalid validate tag on field %s of %s", sf.Name, t)
+		}
+		// Path parameters are always required by definition.
+		if f.In == InPath {
+			f.Required = true
+		}
+		fields = append(fields, f)
+	}
+	return fields, nil
+}
+
+// parseValidateTag parses a comma-separated constraint list such as
+// "required,minLength=1,maxLength=100,enum=a|b,pattern=^[a-z]+$".
+// Because regular expressions may contain commas, pattern must be the last rule
+// and consumes the remainder of the tag.
+func parseValidateTag(f *Field, tag string) error {
+	for tag != "" {
+		var rule string
+		if strings.HasPrefix(tag, "pattern=") {
+			rule, tag = tag, ""
+		} else {
+			rule, tag, _ = strings.Cut(tag, ",")
+		}
+		key, value, _ := strings.Cut(strings.TrimSpace(rule), "=")
+
+		switch key {
+		case "required":
+			f.Required = true
+		case "minLength", "maxLength":
+			n, err := strconv.Atoi(value)
+			if err != nil || n < 0 {
+				return errors.Newf("%s must be a non-negative integer, got %q", key, value)
+			}
+			if key == "minLength" {
+				f.MinLength = &n
+			} else {
+				f.MaxLength = &n
+			}
+		case "minimum", "maximum":
+			n, err := strconv.ParseFloat(value, 64)
+			if err != nil {
+				return errors.Newf("%s must be a number, got %q", key, value)
+			}
+			if key == "minimum" {
+				f.Minimum = &n
+			} else {
+				f.Maximum = &n
+			}
+		case "enum":
+			f.Enum = strings.Split(value, "|")
+		case "pattern":
+			re, err := regexp.Compile(value)
+			if err != nil {
+				return errors.Wrapf(err, "pattern %q does not compile", value)
+			}
+			f.Pattern = re
+		case "":
+			// Tolerate empty rules such as a trailing comma.
+		default:
+			return errors.Newf("unknown rule %q", key)
+		}
+	}
+	return nil
+}
+
+// isScalarOrSlice reports whether t can be bound from string-valued parameters.
+func isScalarOrSlice(t reflect.Type) bool {
+	if t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	if t.Kind() == reflect.Slice {
+		t = t.Elem()
+	}
+	switch t.Kind() {
+	case reflect.String, reflect.Bool,
+		reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		return true
+	default:
+		return false
+	}
+}
+
+// setFromStrings converts raw parameter values into v, which must have a type accepted by isScalarOrSlice.
+func setFromStrings(v reflect.Value, raw []string) error {
+	if v.Kind() == reflect.Pointer {
+		ptr := reflect.New(v.Type().Elem())
+		if err := setFromStrings(ptr.Elem(), raw); err != nil {
+			return err
+		}
+		v.Set(ptr)
+		return nil
+	}
+	if v.Kind() == reflect.Slice {
+		slice := reflect.MakeSlice(v.Type(), len(raw), len(raw))
+		for i, s := range raw {
+			if err := setScalar(slice.Index(i), s); err != nil {
+				return err
+			}
+		}
+		v.Set(slice)
+		return nil
+	}
+	return setScalar(v, raw[0])
+}
+
+// setScalar parses s into the scalar value v.
+func setScalar(v reflect.Value, s string) error {
+	switch v.Kind() {
+	case reflect.String:
+		v.SetString(s)
+	case reflect.Bool:
+		b, err := strconv.ParseBool(s)
+		if err != nil {
+			return errors.New("must be a boolean")
+		}
+		v.SetBool(b)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n, err := strconv.ParseInt(s, 10, v.Type().Bits())
+		if err != nil {
+			return errors.New("must be an integer")
+		}
+		v.SetInt(n)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		n, err := strconv.ParseUint(s, 10, v.Type().Bits())
+		if err != nil {
+			return errors.New("must be a non-negative integer")
+		}
+		v.SetUint(n)
+	case reflect.Float32, reflect.Float64:
+		n, err := strconv.ParseFloat(s, v.Type().Bits())
+		if err != nil {
+			return errors.New("must be a number")
+		}
+		v.SetFloat(n)
+	default:
+		return errors.Newf("unsupported type %s", v.Type())
+	}
+	return nil
+}
+
+// describe returns the human-readable subject used in violation messages, e.g. "The 'name' query parameter".
+func (f Field) describe() string {
+	if f.In == InBody {
+		return fmt.Sprintf("The '%s' body field", f.Name)
+	}
+	return fmt.Sprintf("The '%s' %s parameter", f.Name, f.In)
+}
+
+// validate checks the bound value v against the field's constraints.
+// present reports whether the client supplied the input at all.
+func (f Field) validate(v reflect.Value, present bool) []string {
+	if v.Kind() == reflect.Pointer {
+		if v.IsNil() {
+			present = false
+		} else {
+			v = v.Elem()
+		}
+	}
+	if !present || (v.Kind() == reflect.String && v.Len() == 0) {
+		if f.Required {
+			return []string{"is required."}
+		}
+		return nil
+	}
+
+	var problems []string
+	switch v.Kind() {
+	case reflect.String, reflect.Slice:
+		n := v.Len()
+		unit := "items"
+		if v.Kind() == reflect.String {
+			n = len([]rune(v.String()))
+			unit = "characters long"
+		}
+		if f.MinLength != nil && n < *f.MinLength {
+			problems = append(problems, fmt.Sprintf("must be at least %d %s.", *f.MinLength, unit))
+		}
+		if f.MaxLength != nil && n > *f.MaxLength {
+			problems = append(problems, fmt.Sprintf("must be at most %d %s.", *f.MaxLength, unit))
+		}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		n, _ := strconv.ParseFloat(fmt.Sprint(v.Interface()), 64)
+		if f.Minimum != nil && n < *f.Minimum {
+			problems = append(problems, fmt.Sprintf("must be at least %v.", *f.Minimum))
+		}
+		if f.Maximum != nil && n > *f.Maximum {
+			problems = append(problems, fmt.Sprintf("must be at most %v.", *f.Maximum))
+		}
+	}
+
+	if v.Kind() == reflect.String {
+		s := v.String()
+		if len(f.Enum) > 0 && !contains(f.Enum, s) {
+			problems = append(problems, fmt.Sprintf("must be one of: %s.", strings.Join(f.Enum, ", ")))
+		}
+		if f.Pattern != nil && !f.Pattern.MatchString(s) {
+			problems = append(problems, fmt.Sprintf("must match the pattern %s.", f.Pattern.String()))
+		}
+	}
+	return problems
+}
+
+// contains reports whether s is one of values.
+func contains(values []string, s string) bool {
+	for _, v := range values {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,126 @@
+// file: internal/tools/openapi.go
+package tools
+
+// openapi.go generates the OpenAPI description of the registered tools.
+
+import (
+	"net/http"
+	"reflect"
+
+	"github.com/dkoosis/hello-tool-base/internal/openapi"
+	"github.com/dkoosis/hello-tool-base/internal/respond"
+)
+
+// errorSchemaName is the component name of the standard error body.
+const errorSchemaName = "ErrorResponse"
+
+// OpenAPI generates the OpenAPI document describing every registered tool.
+// Each tool becomes one operation: its request struct yields the parameters and
+// JSON request body, its response type the 200 schema, and every operation documents
+// the standard error body for 400 and 500 responses.
+func (r *Registry) OpenAPI(info openapi.Info, servers []openapi.Server) *openapi.Document {
+	doc := &openapi.Document{
+		OpenAPI: openapi.Version,
+		Info:    info,
+		Servers: servers,
+		Paths:   make(map[string]*openapi.PathItem),
+		Components: &openapi.Components{Schemas: map[string]*openapi.Schema{
+			errorSchemaName: openapi.SchemaFor(reflect.TypeFor[respond.ErrorResponse]()),
+		}},
+	}
+
+	for _, t := range r.tools {
+		item, ok := doc.Paths[t.Path]
+		if !ok {
+			item = &openapi.PathItem{}
+			doc.Paths[t.Path] = item
+		}
+		// Register only accepts methods in httpMethods, which SetOperation always supports.
+		_ = item.SetOperation(t.Method, t.operation())
+	}
+	return doc
+}
+
+// operation builds the OpenAPI operation for the tool.
+func (t *Tool) operation() *openapi.Operation {
+	op := &openapi.Operation{
+		Summary:     t.Summary,
+		Description: t.Description,
+		OperationID: t.Name,
+		Responses: map[string]*openapi.Response{
+			"200": jsonResponse(t.successDescription(), openapi.SchemaFor(t.respType)),
+			"400": jsonResponse("Bad Request - One or more request parameters are missing or invalid.", openapi.Ref(errorSchemaName)),
+			"500": jsonResponse("Internal Server Error - An unexpected error occurred on the server.", openapi.Ref(errorSchemaName)),
+		},
+	}
+
+	body := &openapi.Schema{Type: "object", Properties: make(map[string]*openapi.Schema)}
+	for _, f := range t.fields {
+		if f.In == InBody {
+			body.Properties[f.Name] = f.schema()
+			if f.Required {
+				body.Required = append(body.Required, f.Name)
+			}
+			continue
+		}
+		op.Parameters = append(op.Parameters, &openapi.Parameter{
+			Name:        f.Name,
+			In:          f.In,
+			Required:    f.Required,
+			Description: f.Description,
+			Schema:      f.schema(),
+		})
+	}
+	if len(body.Properties) > 0 {
+		op.RequestBody = &openapi.RequestBody{
+			Required: len(body.Required) > 0,
+			Content:  map[string]*openapi.MediaType{"application/json": {Schema: body}},
+		}
+	}
+	return op
+}
+
+// successDescription returns the description of the 200 response.
+func (t *Tool) successDescription() string {
+	if t.ResponseDescription != "" {
+		return t.ResponseDescription
+	}
+	return "Successful response."
+}
+
+// schema returns the field's schema including its validation constraints.
+// Descriptions of parameters live on the parameter itself, so only body fields carry one here.
+func (f Field) schema() *openapi.Schema {
+	s := openapi.SchemaFor(f.Type)
+	if f.In == InBody {
+		s.Description = f.Description
+	}
+	if s.Type == "array" {
+		s.MinItems, s.MaxItems = f.MinLength, f.MaxLength
+	} else {
+		s.MinLength, s.MaxLength = f.MinLength, f.MaxLength
+	}
+	s.Minimum, s.Maximum = f.Minimum, f.Maximum
+	s.Enum = f.Enum
+	if f.Pattern != nil {
+		s.Pattern = f.Pattern.String()
+	}
+	if f.Example != "" {
+		s.Example = openapi.ExampleValue(f.Type, f.Example)
+	}
+	return s
+}
+
+// jsonResponse builds a response with an application/json body.
+func jsonResponse(description string, schema *openapi.Schema) *openapi.Response {
+	return &openapi.Response{
+		Description: description,
+		Content:     map[string]*openapi.MediaType{"application/json": {Schema: schema}},
+	}
+}
+
+// httpMethods lists the methods representable in an OpenAPI path item.
+var httpMethods = map[string]bool{
+	http.MethodGet: true, http.MethodPut: true, http.MethodPost: true,
+	http.MethodDelete: true, http.MethodPatch: true,
+}
@@ -0,0 +1,74 @@
+// file: internal/tools/registry.go
+package tools
+
+// registry.go holds the set of tools a service exposes.
+
+import (
+	"net/http"
+
+	"github.com/cockroachdb/errors"
+)
+
+// Registry holds the tools exposed by a service, in registration order.
+// It is populated at startup and read concurrently afterwards; it is not safe
+// to register tools while requests are being served.
+type Registry struct {
+	tools  []*Tool
+	byName map[string]*Tool
+}
+
+// NewRegistry creates an empty tool registry.
+func NewRegistry() *Registry {
+	return &Registry{byName: make(map[string]*Tool)}
+}
+
+// Register adds tools to the registry. It fails if a tool has invalid struct tags,
+// is missing its name, method or path, uses an unsupported method, or duplicates
+// an existing name or route.
+func (r *Registry) Register(tools ...*Tool) error {
+	for _, t := range tools {
+		if t.err != nil {
+			return errors.Wrapf(t.err, "Registry.Register: invalid request type for tool %q", t.Name)
+		}
+		if t.Name == "" || t.Method == "" || t.Path == "" {
+			return errors.Newf("Registry.Register: tool %q must declare a name, method and path", t.Name)
+		}
+		if !httpMethods[t.Method] {
+			return errors.Newf("Registry.Register: tool %q uses unsupported HTTP method %q", t.Name, t.Method)
+		}
+		if _, exists := r.byName[t.Name]; exists {
+			return errors.Newf("Registry.Register: tool %q is already registered", t.Name)
+		}
+		for _, existing := range r.tools {
+			if existing.Pattern() == t.Pattern() {
+				return errors.Newf("Registry.Register: tools %q and %q share the route %q", existing.Name, t.Name, t.Pattern())
+			}
+		}
+		r.tools = append(r.tools, t)
+		r.byName[t.Name] = t
+	}
+	return nil
+}
+
+// Tools returns the registered tools in registration order.
+func (r *Registry) Tools() []*Tool {
+	return append([]*Tool(nil), r.tools...)
+}
+
+// Lookup returns the tool with the given name, or false if none is registered.
+func (r *Registry) Lookup(name string) (*Tool, bool) {
+	t, ok := r.byName[name]
+	return t, ok
+}
+
+// Mux is the route registration interface satisfied by *http.ServeMux and *server.Server.
+type Mux interface {
+	Handle(pattern string, handler http.Handler)
+}
+
+// Mount registers every tool's HTTP route on mux.
+func (r *Registry) Mount(mux Mux) {
+	for _, t := range r.tools {
+		mux.Handle(t.Pattern(), t)
+	}
+}
@@ -0,0 +1,23 @@
+// file: internal/tools/rpc.go
+package tools
+
+// rpc.go lets a registry answer JSON-RPC requests, with the tool name as the method.
+
+import (
+	"context"
+
+	"github.com/dkoosis/hello-tool-base/internal/apperrors"
+	"github.com/dkoosis/hello-tool-base/internal/jsonrpc"
+)
+
+// HandleRPC implements jsonrpc.Handler. The method names a registered tool and the
+// params are its arguments as a JSON object (see Tool.Call); positional params are
+// rejected as invalid.
+func (r *Registry) HandleRPC(ctx context.Context, req *jsonrpc.Request) (any, error) {
+	t, ok := r.Lookup(req.Method)
+	if !ok {
+		return nil, apperrors.NewMethodNotFoundError("tools: no tool named "+req.Method, nil,
+			map[string]interface{}{"method": req.Method})
+	}
+	return t.Call(ctx, req.Params)
+}
@@ -0,0 +1,228 @@
+// Package tools provides a declarative registry for the operations a service exposes
+// to agents. A tool is declared once with a Go request struct, whose tags describe
+// where each input comes from and how it is validated, and a typed handler function.
+// The package binds and validates inputs, reports every validation failure as a
+// structured 400 response, and encodes results, so tool code is just business logic.
+// file: internal/tools/tool.go
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"reflect"
+
+	"github.com/cockroachdb/errors"
+	"github.com/dkoosis/hello-tool-base/internal/apperrors"
+	"github.com/dkoosis/hello-tool-base/internal/logging"
+	"github.com/dkoosis/hello-tool-base/internal/middleware"
+	"github.com/dkoosis/hello-tool-base/internal/openapi"
+	"github.com/dkoosis/hello-tool-base/internal/respond"
+)
+
+// MaxBodySize is the largest JSON request body, in bytes, ServeHTTP reads. It is the
+// limit middleware.OpenAPIValidation applies before the tool sees the request.
+const MaxBodySize = middleware.MaxRequestBodySize
+
+// toolContextKey is the context key of the name of the tool handling a request.
+type toolContextKey struct{}
+
+// Loggers enriched with Logger.WithContext include the tool name.
+func init() {
+	logging.RegisterContextField(logging.ToolKey, logging.StringFromContext(toolContextKey{}))
+}
+
+// ContextWithTool stores the name of the tool handling a request in ctx. ServeHTTP and
+// Call do this before invoking the handler.
+func ContextWithTool(ctx context.Context, name string) context.Context {
+	return context.WithValue(ctx, toolContextKey{}, name)
+}
+
+// ToolFromContext returns the name of the tool handling the request in ctx, or "".
+func ToolFromContext(ctx context.Context) string {
+	name, _ := ctx.Value(toolContextKey{}).(string)
+	return name
+}
+
+// HandlerFunc is the business logic of a tool. It receives a bound and validated request
+// and returns the response to encode, or an error. Returning an apperrors error controls
+// the HTTP status and client message; any other error is reported as a 500.
+type HandlerFunc[Req, Resp any] func(ctx context.Context, req Req) (Resp, error)
+
+// Spec describes how a tool is exposed.
+type Spec struct {
+	// Name uniquely identifies the tool. It is also used as the OpenAPI operationId.
+	Name string
+	// Method is the HTTP method the tool is served on, e.g. http.MethodGet.
+	Method string
+	// Path is the HTTP route, which may contain {param} segments bound by `path` tags.
+	Path string
+	// Summary is a one-line description of the tool.
+	Summary string
+	// Description explains in natural language what the tool does and when to use it.
+	Description string
+	// ResponseDescription documents the successful (200) response.
+	ResponseDescription string
+}
+
+// Tool is a registered operation with its binding metadata and handler.
+// Tool implements http.Handler.
+type Tool struct {
+	Spec
+
+	reqType  reflect.Type
+	respType reflect.Type
+	fields   []Field
+	err      error
+	invoke   func(ctx context.Context, req reflect.Value) (any, error)
+}
+
+// New declares a tool from a spec and a typed handler.
+//
+// Req must be a struct. Its exported fields are bound using these tags:
+//   - `query:"name"`, `header:"X-Name"` or `path:"name"` bind an HTTP parameter;
+//   - any other field with a `json:"name"` tag is decoded from the JSON request body;
+//   - `validate:"required,minLength=1,maxLength=100,minimum=0,maximum=10,enum=a|b,pattern=^x+$"`
+//     declares constraints (pattern, if present, must come last);
+//   - `description:"..."` and `example:"..."` document the field.
+//
+// Invalid tags are reported when the tool is registered.
+func New[Req, Resp any](spec Spec, fn HandlerFunc[Req, Resp]) *Tool {
+	t := &Tool{
+		Spec:     spec,
+		reqType:  reflect.TypeFor[Req](),
+		respType: reflect.TypeFor[Resp](),
+	}
+	t.fields, t.err = parseFields(t.reqType)
+	t.invoke = func(ctx context.Context, req reflect.Value) (any, error) {
+		return fn(ctx, req.Interface().(Req))
+	}
+	return t
+}
+
+// Fields returns the binding metadata of the tool's request struct.
+func (t *Tool) Fields() []Field {
+	return t.fields
+}
+
+// RequestType returns the Go type of the tool's request struct.
+func (t *Tool) RequestType() reflect.Type {
+	return t.reqType
+}
+
+// ResponseType returns the Go type of the tool's successful response.
+func (t *Tool) ResponseType() reflect.Type {
+	return t.respType
+}
+
+// Pattern returns the http.ServeMux pattern the tool is mounted on, e.g. "GET /hello".
+func (t *Tool) Pattern() string {
+	return t.Method + " " + t.Path
+}
+
+// ServeHTTP binds and validates the request, invokes the handler and writes the response.
+// The tool name is stored in the context, so request-scoped loggers include it.
+func (t *Tool) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	ctx := ContextWithTool(r.Context(), t.Name)
+	logger := middleware.GetLoggerFromContext(ctx)
+	logger.Info("Received request", "remote_addr", r.RemoteAddr, "path", r.URL.Path)
+
+	if r.Body != nil {
+		r.Body = http.MaxBytesReader(w, r.Body, MaxBodySize)
+	}
+	req, err := t.bindHTTP(r)
+	if err != nil {
+		respond.Error(logger, w, err)
+		return
+	}
+
+	resp, err := t.invoke(ctx, req)
+	if err != nil {
+		respond.Error(logger, w, err)
+		return
+	}
+	respond.JSON(logger, w, http.StatusOK, resp)
+	logger.Info("Successfully processed request")
+}
+
+// bindHTTP populates a new request value from r and validates it.
+// All violations are collected and returned together as a single validation error.
+func (t *Tool) bindHTTP(r *http.Request) (reflect.Value, error) {
+	req := reflect.New(t.reqType).Elem()
+	var violations []apperrors.Violation
+	// bodyKeys holds the properties the body has, so a body field sent as false or 0
+	// counts as present.
+	var bodyKeys map[string]json.RawMessage
+
+	if t.hasBody() && r.Body != nil && r.Body != http.NoBody {
+		body, err := io.ReadAll(r.Body)
+		var tooLarge *http.MaxBytesError
+		switch {
+		case errors.As(err, &tooLarge):
+			violations = append(violations, apperrors.Violation{
+				Field: "body", In: InBody, Pointer: openapi.Pointer(InBody), Message: fmt.Sprintf("The request body must be at most %d bytes.", MaxBodySize),
+			})
+		case err != nil:
+			return req, errors.Wrapf(err, "tools: failed to read request body for %s", t.Name)
+		case len(body) > 0:
+			if err := json.Unmarshal(body, req.Addr().Interface()); err != nil {
+				violations = append(violations, apperrors.Violation{
+					Field: "body", In: InBody, Pointer: openapi.Pointer(InBody), Message: "The request body must be a valid JSON object: " + err.Error(),
+				})
+			} else {
+				_ = json.Unmarshal(body, &bodyKeys) // Valid for the request struct, so an object.
+			}
+		}
+	}
+
+	query := r.URL.Query()
+	for _, f := range t.fields {
+		var raw []string
+		present := true
+		switch f.In {
+		case InQuery:
+			raw, present = query[f.Name]
+		case InHeader:
+			raw, present = r.Header.Values(f.Name), len(r.Header.Values(f.Name)) > 0
+		case InPath:
+			raw = []string{r.PathValue(f.Name)}
+			present = raw[0] != ""
+		case InBody:
+			value, ok := bodyKeys[f.Name]
+			present = ok && string(value) != "null"
+		}
+
+		v := req.FieldByIndex(f.index)
+		if f.In != InBody && present {
+			if err := setFromStrings(v, raw); err != nil {
+				violations = append(violations, apperrors.Violation{
+					Field: f.Name, In: f.In, Pointer: openapi.Pointer(f.In, f.Name), Message: f.describe() + " " + err.Error() + ".",
+				})
+				continue
+			}
+		}
+		for _, problem := range f.validate(v, present) {
+			violations = append(violations, apperrors.Violation{
+				Field: f.Name, In: f.In, Pointer: openapi.Pointer(f.In, f.Name), Message: f.describe() + " " + problem,
+			})
+		}
+	}
+
+	if len(violations) > 0 {
+		return req, apperrors.NewValidationError("tools: request validation failed for "+t.Name, violations,
+			map[string]interface{}{"toolName": t.Name, "method": r.Method, "query_path": r.URL.String()})
+	}
+	return req, nil
+}
+
+// hasBody reports whether any field of the request struct is read from the JSON body.
+func (t *Tool) hasBody() bool {
+	for _, f := range t.fields {
+		if f.In == InBody {
+			return true
+		}
+	}
+	return false
+}
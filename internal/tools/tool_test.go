@@ -0,0 +1,181 @@
+// file: internal/tools/tool_test.go
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dkoosis/hello-tool-base/internal/apperrors"
+	"github.com/dkoosis/hello-tool-base/internal/respond"
+)
+
+type orderRequest struct {
+	Tenant   string `header:"X-Tenant" validate:"required"`
+	Currency string `query:"currency" validate:"enum=usd|eur"`
+	Item     string `json:"item" validate:"required,maxLength=5"`
+	Quantity int    `json:"quantity" validate:"minimum=1,maximum=10"`
+}
+
+type orderResponse struct {
+	Summary string `json:"summary"`
+}
+
+func newOrderTool(fn HandlerFunc[orderRequest, orderResponse]) *Tool {
+	return New(Spec{Name: "createOrder", Method: http.MethodPost, Path: "/orders"}, fn)
+}
+
+// TestTool_BindsAllSources_When_RequestIsValid (ADR-008 Naming)
+func TestTool_BindsAllSources_When_RequestIsValid(t *testing.T) {
+	// Arrange
+	var got orderRequest
+	tool := newOrderTool(func(_ context.Context, req orderRequest) (orderResponse, error) {
+		got = req
+		return orderResponse{Summary: "ok"}, nil
+	})
+	req := httptest.NewRequest(http.MethodPost, "/orders?currency=eur", strings.NewReader(`{"item":"hat","quantity":2}`))
+	req.Header.Set("X-Tenant", "acme")
+	rr := httptest.NewRecorder()
+
+	// Act
+	tool.ServeHTTP(rr, req)
+
+	// Assert
+	assert.Equal(t, http.StatusOK, rr.Code)
+	assert.Equal(t, orderRequest{Tenant: "acme", Currency: "eur", Item: "hat", Quantity: 2}, got)
+}
+
+// TestTool_ReportsEveryViolation_When_SeveralInputsAreInvalid (ADR-008 Naming)
+func TestTool_ReportsEveryViolation_When_SeveralInputsAreInvalid(t *testing.T) {
+	// Arrange
+	called := false
+	tool := newOrderTool(func(_ context.Context, _ orderRequest) (orderResponse, error) {
+		called = true
+		return orderResponse{}, nil
+	})
+	req := httptest.NewRequest(http.MethodPost, "/orders?currency=gbp", strings.NewReader(`{"item":"umbrella","quantity":0}`))
+	rr := httptest.NewRecorder()
+
+	// Act
+	tool.ServeHTTP(rr, req)
+
+	// Assert
+	assert.False(t, called, "handler must not run when validation fails")
+	assert.Equal(t, http.StatusBadRequest, rr.Code)
+	var body respond.ErrorResponse
+	require.NoError(t, json.NewDecoder(rr.Body).Decode(&body))
+	fields := make([]string, 0, len(body.Violations))
+	for _, v := range body.Violations {
+		fields = append(fields, v.In+":"+v.Field)
+	}
+	assert.ElementsMatch(t, []string{"header:X-Tenant", "query:currency", "body:item", "body:quantity"}, fields)
+}
+
+// TestTool_AcceptsZeroValues_When_RequiredBodyFieldsAreFalseOrZero (ADR-008 Naming)
+func TestTool_AcceptsZeroValues_When_RequiredBodyFieldsAreFalseOrZero(t *testing.T) {
+	// Arrange
+	type toggleRequest struct {
+		On    bool `json:"on" validate:"required"`
+		Count int  `json:"count" validate:"required"`
+	}
+	tool := New(Spec{Name: "toggle", Method: http.MethodPost, Path: "/toggle"},
+		func(_ context.Context, _ toggleRequest) (struct{}, error) { return struct{}{}, nil })
+	zero := httptest.NewRequest(http.MethodPost, "/toggle", strings.NewReader(`{"on":false,"count":0}`))
+	missing := httptest.NewRequest(http.MethodPost, "/toggle", strings.NewReader(`{"count":null}`))
+	zeroRR, missingRR := httptest.NewRecorder(), httptest.NewRecorder()
+
+	// Act
+	tool.ServeHTTP(zeroRR, zero)
+	tool.ServeHTTP(missingRR, missing)
+
+	// Assert
+	assert.Equal(t, http.StatusOK, zeroRR.Code, zeroRR.Body.String())
+	require.Equal(t, http.StatusBadRequest, missingRR.Code)
+	var body respond.ErrorResponse
+	require.NoError(t, json.NewDecoder(missingRR.Body).Decode(&body))
+	fields := make([]string, 0, len(body.Violations))
+	for _, v := range body.Violations {
+		fields = append(fields, v.Field)
+	}
+	assert.ElementsMatch(t, []string{"on", "count"}, fields)
+}
+
+// TestTool_RejectsBody_When_LargerThanMaxBodySize (ADR-008 Naming)
+func TestTool_RejectsBody_When_LargerThanMaxBodySize(t *testing.T) {
+	// Arrange
+	called := false
+	tool := newOrderTool(func(_ context.Context, _ orderRequest) (orderResponse, error) {
+		called = true
+		return orderResponse{}, nil
+	})
+	body := `{"item":"hat","quantity":1,"note":"` + strings.Repeat("a", MaxBodySize) + `"}`
+	req := httptest.NewRequest(http.MethodPost, "/orders", strings.NewReader(body))
+	req.Header.Set("X-Tenant", "acme")
+	rr := httptest.NewRecorder()
+
+	// Act
+	tool.ServeHTTP(rr, req)
+
+	// Assert
+	assert.False(t, called)
+	assert.Equal(t, http.StatusBadRequest, rr.Code)
+	assert.Contains(t, rr.Body.String(), "must be at most")
+}
+
+// TestTool_MapsApplicationError_When_HandlerFails (ADR-008 Naming)
+func TestTool_MapsApplicationError_When_HandlerFails(t *testing.T) {
+	// Arrange
+	tool := newOrderTool(func(_ context.Context, _ orderRequest) (orderResponse, error) {
+		return orderResponse{}, apperrors.NewResourceError(apperrors.ErrResourceNotFound, "item not stocked", nil, nil)
+	})
+	req := httptest.NewRequest(http.MethodPost, "/orders", strings.NewReader(`{"item":"hat","quantity":1}`))
+	req.Header.Set("X-Tenant", "acme")
+	rr := httptest.NewRecorder()
+
+	// Act
+	tool.ServeHTTP(rr, req)
+
+	// Assert
+	assert.Equal(t, http.StatusNotFound, rr.Code)
+}
+
+// TestRegistry_RejectsTool_When_NameIsDuplicated (ADR-008 Naming)
+func TestRegistry_RejectsTool_When_NameIsDuplicated(t *testing.T) {
+	noop := func(_ context.Context, _ orderRequest) (orderResponse, error) { return orderResponse{}, nil }
+	registry := NewRegistry()
+	require.NoError(t, registry.Register(newOrderTool(noop)))
+	assert.Error(t, registry.Register(newOrderTool(noop)))
+}
+
+// TestRegistry_RejectsTool_When_ValidateTagIsInvalid (ADR-008 Naming)
+func TestRegistry_RejectsTool_When_ValidateTagIsInvalid(t *testing.T) {
+	type badRequest struct {
+		Name string `query:"name" validate:"maxLen=3"`
+	}
+	tool := New(Spec{Name: "bad", Method: http.MethodGet, Path: "/bad"},
+		func(_ context.Context, _ badRequest) (struct{}, error) { return struct{}{}, nil })
+	assert.Error(t, NewRegistry().Register(tool))
+}
+
+// TestTool_StoresToolNameInContext_When_Called (ADR-008 Naming)
+func TestTool_StoresToolNameInContext_When_Called(t *testing.T) {
+	// Arrange
+	var got string
+	tool := newOrderTool(func(ctx context.Context, _ orderRequest) (orderResponse, error) {
+		got = ToolFromContext(ctx)
+		return orderResponse{}, nil
+	})
+
+	// Act
+	_, err := tool.Call(context.Background(), json.RawMessage(`{"X-Tenant":"acme","currency":"usd","item":"tea","quantity":1}`))
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, "createOrder", got)
+}
@@ -0,0 +1,73 @@
+// file: internal/transport/memory.go
+package transport
+
+// memory.go provides linked in-memory transport pairs for running a client and server in one process.
+
+import (
+	"context"
+	"net"
+	"sync"
+)
+
+// ChannelTransport is one end of an in-memory pair created by NewChannelPair.
+// Messages are handed over unbuffered, so a writer blocks until the peer reads,
+// and are delivered verbatim with no framing.
+type ChannelTransport struct {
+	in         <-chan []byte
+	out        chan<- []byte
+	closed     chan struct{}
+	peerClosed <-chan struct{}
+	closeOnce  sync.Once
+}
+
+// NewChannelPair returns two linked transports: messages written to one are read from
+// the other. Closing either end makes pending and later operations on both ends
+// return ErrClosed.
+func NewChannelPair() (*ChannelTransport, *ChannelTransport) {
+	aToB, bToA := make(chan []byte), make(chan []byte)
+	aClosed, bClosed := make(chan struct{}), make(chan struct{})
+	a := &ChannelTransport{in: bToA, out: aToB, closed: aClosed, peerClosed: bClosed}
+	b := &ChannelTransport{in: aToB, out: bToA, closed: bClosed, peerClosed: aClosed}
+	return a, b
+}
+
+// ReadMessage waits for the peer's next message.
+func (t *ChannelTransport) ReadMessage(ctx context.Context) ([]byte, error) {
+	select {
+	case msg := <-t.in:
+		return msg, nil
+	case <-t.closed:
+		return nil, ErrClosed
+	case <-t.peerClosed:
+		return nil, ErrClosed
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+// WriteMessage hands a copy of msg to the peer, waiting until it is read.
+func (t *ChannelTransport) WriteMessage(ctx context.Context, msg []byte) error {
+	select {
+	case t.out <- append([]byte(nil), msg...):
+		return nil
+	case <-t.closed:
+		return ErrClosed
+	case <-t.peerClosed:
+		return ErrClosed
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// Close closes this end, which also closes the pair for the peer.
+func (t *ChannelTransport) Close() error {
+	t.closeOnce.Do(func() { close(t.closed) })
+	return nil
+}
+
+// NewPipePair returns two NDJSON transports linked by a synchronous net.Pipe, so messages
+// go through the same framing as the stdio transport. Closing either end closes the pipe.
+func NewPipePair() (*NDJSONTransport, *NDJSONTransport) {
+	a, b := net.Pipe()
+	return NewNDJSONTransport(a, a, a), NewNDJSONTransport(b, b, b)
+}
@@ -0,0 +1,65 @@
+// file: internal/transport/memory_test.go
+package transport
+
+import (
+	"context"
+	"testing"
+
+	"github.com/cockroachdb/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestChannelPair_DeliversMessage_When_PeerReads (ADR-008 Naming)
+func TestChannelPair_DeliversMessage_When_PeerReads(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	a, b := NewChannelPair()
+	written := make(chan error, 1)
+
+	// Act
+	go func() { written <- a.WriteMessage(ctx, []byte(`{"n":1}`)) }()
+	got, err := b.ReadMessage(ctx)
+
+	// Assert
+	require.NoError(t, err)
+	require.NoError(t, <-written)
+	assert.Equal(t, `{"n":1}`, string(got))
+}
+
+// TestChannelPair_ReturnsErrClosed_When_PeerCloses (ADR-008 Naming)
+func TestChannelPair_ReturnsErrClosed_When_PeerCloses(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	a, b := NewChannelPair()
+
+	// Act
+	require.NoError(t, a.Close())
+	_, readErr := b.ReadMessage(ctx)
+	writeErr := b.WriteMessage(ctx, []byte(`{}`))
+
+	// Assert
+	assert.True(t, errors.Is(readErr, ErrClosed))
+	assert.True(t, errors.Is(writeErr, ErrClosed))
+}
+
+// TestPipePair_FramesOneLinePerMessage_When_MessageHasNewlines (ADR-008 Naming)
+func TestPipePair_FramesOneLinePerMessage_When_MessageHasNewlines(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	a, b := NewPipePair()
+	t.Cleanup(func() { _ = a.Close(); _ = b.Close() })
+	written := make(chan error, 1)
+
+	// Act
+	go func() { written <- a.WriteMessage(ctx, []byte("{\n  \"n\": 1\n}")) }()
+	got, err := b.ReadMessage(ctx)
+	require.NoError(t, <-written)
+	require.NoError(t, a.Close())
+	_, closedErr := b.ReadMessage(ctx)
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, `{"n":1}`, string(got))
+	assert.True(t, errors.Is(closedErr, ErrClosed))
+}
@@ -0,0 +1,169 @@
+// Package transport moves framed protocol messages (e.g. MCP JSON-RPC messages) between
+// peers. A Transport reads and writes whole messages, hiding the framing used on the
+// wire; the NDJSON transport frames each message as one line of JSON, as used by MCP
+// over stdio.
+// file: internal/transport/transport.go
+package transport
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net"
+	"sync"
+	"syscall"
+
+	"github.com/cockroachdb/errors"
+)
+
+// MaxMessageSize is the largest message, in bytes, an NDJSON transport will read.
+const MaxMessageSize = 4 << 20
+
+// ErrMessageTooLarge is returned by an NDJSON transport's ReadMessage for a message
+// longer than MaxMessageSize. The message is skipped, so the transport stays usable and
+// the next read returns the message after it.
+var ErrMessageTooLarge = errors.Newf("transport: message exceeds %d bytes", MaxMessageSize)
+
+// ErrClosed is returned by operations on a transport that has been closed,
+// and by ReadMessage when the peer has closed its end.
+var ErrClosed = errors.New("transport: closed")
+
+// Transport sends and receives whole messages.
+// ReadMessage and WriteMessage may be called concurrently with each other,
+// but each must not be called concurrently with itself.
+type Transport interface {
+	// ReadMessage blocks until a message arrives, ctx is done or the transport closes.
+	ReadMessage(ctx context.Context) ([]byte, error)
+	// WriteMessage sends one message.
+	WriteMessage(ctx context.Context, msg []byte) error
+	// Close releases the transport. Pending reads return ErrClosed.
+	Close() error
+}
+
+// NDJSONTransport frames messages as newline-delimited JSON over a reader and writer,
+// such as os.Stdin and os.Stdout.
+type NDJSONTransport struct {
+	r      *bufio.Reader
+	w      io.Writer
+	closer io.Closer
+
+	writeMu   sync.Mutex
+	closeOnce sync.Once
+	closed    chan struct{}
+}
+
+// NewNDJSONTransport creates an NDJSON transport. closer, if non-nil, is closed by Close,
+// which unblocks pending reads on readers such as pipes and network connections.
+func NewNDJSONTransport(r io.Reader, w io.Writer, closer io.Closer) *NDJSONTransport {
+	return &NDJSONTransport{r: bufio.NewReaderSize(r, 64*1024), w: w, closer: closer, closed: make(chan struct{})}
+}
+
+// ReadMessage returns the next non-empty line. Lines are returned as-is; it is up to
+// the protocol layer to reject lines that are not valid JSON. A line longer than
+// MaxMessageSize is skipped and reported as ErrMessageTooLarge.
+// ctx is only checked between lines: a blocked read is unblocked by Close.
+func (t *NDJSONTransport) ReadMessage(ctx context.Context) ([]byte, error) {
+	for {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		select {
+		case <-t.closed:
+			return nil, ErrClosed
+		default:
+		}
+		line, err := t.readLine()
+		switch {
+		case errors.Is(err, ErrMessageTooLarge):
+			return nil, err
+		case errors.Is(err, io.EOF) || (err != nil && t.isClosed(err)):
+			return nil, ErrClosed
+		case err != nil:
+			return nil, errors.Wrap(err, "NDJSONTransport.ReadMessage: failed to read message")
+		}
+		line = bytes.TrimSpace(line)
+		if len(line) == 0 {
+			continue
+		}
+		return line, nil
+	}
+}
+
+// readLine reads up to and including the next newline, or to the end of the input.
+// Past MaxMessageSize it stops keeping the line but reads on to its end, so the next
+// call starts at the following line.
+func (t *NDJSONTransport) readLine() ([]byte, error) {
+	var line []byte
+	tooLarge := false
+	for {
+		chunk, err := t.r.ReadSlice('\n')
+		if !tooLarge && len(line)+len(bytes.TrimRight(chunk, "\r\n")) > MaxMessageSize {
+			tooLarge, line = true, nil
+		}
+		if !tooLarge {
+			line = append(line, chunk...)
+		}
+		switch {
+		case errors.Is(err, bufio.ErrBufferFull):
+			continue
+		case tooLarge:
+			return nil, ErrMessageTooLarge
+		case errors.Is(err, io.EOF) && len(line) > 0:
+			return line, nil // A last line without a newline.
+		}
+		return line, err
+	}
+}
+
+// WriteMessage writes msg as a single line. Valid JSON is compacted first so that
+// embedded newlines cannot break the framing.
+func (t *NDJSONTransport) WriteMessage(ctx context.Context, msg []byte) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	var buf bytes.Buffer
+	if err := json.Compact(&buf, msg); err != nil {
+		return errors.Wrap(err, "NDJSONTransport.WriteMessage: message is not valid JSON")
+	}
+	buf.WriteByte('\n')
+
+	t.writeMu.Lock()
+	defer t.writeMu.Unlock()
+	select {
+	case <-t.closed:
+		return ErrClosed
+	default:
+	}
+	if _, err := t.w.Write(buf.Bytes()); err != nil {
+		if t.isClosed(err) {
+			return ErrClosed
+		}
+		return errors.Wrap(err, "NDJSONTransport.WriteMessage: failed to write message")
+	}
+	return nil
+}
+
+// isClosed reports whether err means the connection is gone: this transport was closed
+// while blocked, or the peer closed a pipe or socket.
+func (t *NDJSONTransport) isClosed(err error) bool {
+	select {
+	case <-t.closed:
+		return true
+	default:
+	}
+	return errors.Is(err, io.ErrClosedPipe) || errors.Is(err, net.ErrClosed) || errors.Is(err, syscall.EPIPE)
+}
+
+// Close marks the transport closed and closes the underlying closer, if any.
+func (t *NDJSONTransport) Close() error {
+	var err error
+	t.closeOnce.Do(func() {
+		close(t.closed)
+		if t.closer != nil {
+			err = t.closer.Close()
+		}
+	})
+	return err
+}
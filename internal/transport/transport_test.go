@@ -0,0 +1,34 @@
+// file: internal/transport/transport_test.go
+package transport
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/cockroachdb/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestNDJSONTransport_SkipsToNextLine_When_LineExceedsMaxMessageSize (ADR-008 Naming)
+func TestNDJSONTransport_SkipsToNextLine_When_LineExceedsMaxMessageSize(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	input := strings.Repeat("x", MaxMessageSize+1) + "\n" + `{"n":1}` + "\n" + `{"n":2}`
+	tr := NewNDJSONTransport(strings.NewReader(input), nil, nil)
+
+	// Act
+	_, tooLargeErr := tr.ReadMessage(ctx)
+	next, nextErr := tr.ReadMessage(ctx)
+	last, lastErr := tr.ReadMessage(ctx)
+	_, endErr := tr.ReadMessage(ctx)
+
+	// Assert
+	assert.True(t, errors.Is(tooLargeErr, ErrMessageTooLarge))
+	require.NoError(t, nextErr)
+	assert.Equal(t, `{"n":1}`, string(next))
+	require.NoError(t, lastErr)
+	assert.Equal(t, `{"n":2}`, string(last))
+	assert.True(t, errors.Is(endErr, ErrClosed))
+}
@@ -0,0 +1,97 @@
+// Package transporttest injects faults into transports so tests can exercise how the
+// protocol stack copes with malformed frames, slow peers and connections that drop.
+// file: internal/transport/transporttest/transporttest.go
+package transporttest
+
+import (
+	"context"
+	"net"
+	"sync"
+	"time"
+
+	"github.com/dkoosis/hello-tool-base/internal/transport"
+)
+
+// Faults describes the misbehaviour of a wrapped transport. The zero value injects nothing.
+type Faults struct {
+	// ReadDelay is waited before each read, simulating a slow reader.
+	ReadDelay time.Duration
+	// WriteDelay is waited before each write, simulating a slow writer or link.
+	WriteDelay time.Duration
+	// CloseAfterWrites closes the transport abruptly once this many messages have been
+	// written; later operations return transport.ErrClosed. Zero never closes.
+	CloseAfterWrites int
+	// Corrupt, if set, rewrites each outgoing message before it is sent. Invalid JSON
+	// only reaches the peer over the in-memory channel transport: NDJSONTransport's
+	// WriteMessage rejects it. Inject invalid JSON and framing faults over NDJSON by
+	// writing to the conn returned by NewRawPipe instead.
+	Corrupt func(msg []byte) []byte
+}
+
+// faulty wraps a transport with injected faults.
+type faulty struct {
+	transport.Transport
+	faults Faults
+
+	mu     sync.Mutex
+	writes int
+}
+
+// Inject wraps t so that it misbehaves as described by f.
+func Inject(t transport.Transport, f Faults) transport.Transport {
+	return &faulty{Transport: t, faults: f}
+}
+
+// ReadMessage waits ReadDelay before reading.
+func (f *faulty) ReadMessage(ctx context.Context) ([]byte, error) {
+	if err := sleep(ctx, f.faults.ReadDelay); err != nil {
+		return nil, err
+	}
+	return f.Transport.ReadMessage(ctx)
+}
+
+// WriteMessage waits WriteDelay, applies Corrupt and closes the transport once
+// CloseAfterWrites messages have been written.
+func (f *faulty) WriteMessage(ctx context.Context, msg []byte) error {
+	if err := sleep(ctx, f.faults.WriteDelay); err != nil {
+		return err
+	}
+	if f.faults.Corrupt != nil {
+		msg = f.faults.Corrupt(msg)
+	}
+	if err := f.Transport.WriteMessage(ctx, msg); err != nil {
+		return err
+	}
+
+	f.mu.Lock()
+	f.writes++
+	closeNow := f.faults.CloseAfterWrites > 0 && f.writes == f.faults.CloseAfterWrites
+	f.mu.Unlock()
+	if closeNow {
+		return f.Transport.Close()
+	}
+	return nil
+}
+
+// sleep waits for d or until ctx is done.
+func sleep(ctx context.Context, d time.Duration) error {
+	if d <= 0 {
+		return nil
+	}
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// NewRawPipe returns an NDJSON transport together with the raw connection of its peer.
+// Tests write arbitrary bytes to conn (partial lines, invalid JSON, oversized frames)
+// to inject framing errors that a well-behaved transport could never send.
+func NewRawPipe() (t *transport.NDJSONTransport, conn net.Conn) {
+	a, b := net.Pipe()
+	return transport.NewNDJSONTransport(a, a, a), b
+}
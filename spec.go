@@ -0,0 +1,14 @@
+// Package hellotoolbase embeds repository-level assets that the service needs at
+// runtime but that live outside any Go package directory, such as the committed
+// OpenAPI description.
+// file: spec.go
+package hellotoolbase
+
+import (
+	_ "embed" // Required for go:embed.
+)
+
+// OpenAPISpec is the committed openapi.yaml, the contract published to Vertex AI.
+//
+//go:embed openapi.yaml
+var OpenAPISpec []byte